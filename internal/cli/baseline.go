@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/engine"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+)
+
+const baselineUsage = `Usage: guardian baseline <create|prune>
+
+Manage the violation baseline in .agreements/baseline.yml. Violations
+recorded in the baseline are reported separately by 'guardian check' and
+do not fail the build, so only new violations block a change.
+
+Subcommands:
+  create    Scan all tracked files at HEAD and record every current violation
+  prune     Drop baseline entries (or occurrences) that no longer occur at HEAD
+
+Flags:
+  --help     Show this help message
+
+Exit codes:
+  0  Baseline written successfully
+  2  Error occurred
+`
+
+func runBaseline(args []string) int {
+	fs := flag.NewFlagSet("baseline", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, baselineUsage) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Error: subcommand required (create or prune)")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprint(os.Stderr, baselineUsage)
+		return 2
+	}
+
+	subcommand := fs.Arg(0)
+
+	switch subcommand {
+	case "create":
+		return runBaselineCreate()
+	case "prune":
+		return runBaselinePrune()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown baseline subcommand %q; use create or prune\n", subcommand)
+		return 2
+	}
+}
+
+func runBaselineCreate() int {
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	violations, err := scanViolations(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Identical violations in a file share one entry that counts them.
+	index := make(map[string]int, len(violations))
+	baseline := &config.Baseline{}
+	for _, v := range violations {
+		entry := engine.NewBaselineEntry(v)
+		if i, ok := index[entry.Fingerprint]; ok {
+			baseline.Entries[i].Count = baseline.Entries[i].Occurrences() + 1
+			continue
+		}
+		index[entry.Fingerprint] = len(baseline.Entries)
+		baseline.Entries = append(baseline.Entries, entry)
+	}
+	sortBaselineEntries(baseline.Entries)
+
+	path := filepath.Join(agreementsDir, "baseline.yml")
+	if err := config.SaveBaseline(path, baseline); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Fprintf(os.Stdout, "Baseline created: %d violation(s) recorded in %s\n", len(violations), path)
+	printGitHint(path)
+	return 0
+}
+
+func runBaselinePrune() int {
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	path := filepath.Join(agreementsDir, "baseline.yml")
+	baseline, err := config.LoadBaseline(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading baseline: %v\n", err)
+		return 2
+	}
+
+	violations, err := scanViolations(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	current := make(map[string]int, len(violations))
+	for _, v := range violations {
+		current[engine.Fingerprint(v)]++
+	}
+
+	// An entry keeps as many occurrences as still occur, and goes once none
+	// do.
+	kept := make([]config.BaselineEntry, 0, len(baseline.Entries))
+	removed, remaining := 0, 0
+	for _, entry := range baseline.Entries {
+		n := min(entry.Occurrences(), current[entry.Fingerprint])
+		current[entry.Fingerprint] -= n
+		removed += entry.Occurrences() - n
+		remaining += n
+		if n == 0 {
+			continue
+		}
+		entry.Count = 0
+		if n > 1 {
+			entry.Count = n
+		}
+		kept = append(kept, entry)
+	}
+
+	if removed == 0 {
+		fmt.Fprintln(os.Stdout, "Baseline is up to date; nothing to prune.")
+		return 0
+	}
+
+	baseline.Entries = kept
+	if err := config.SaveBaseline(path, baseline); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed %d resolved violation(s) from the baseline; %d remaining.\n", removed, remaining)
+	printGitHint(path)
+	return 0
+}
+
+// scanViolations runs all rules against every file tracked at HEAD, with
+// exceptions and inline suppressions applied as by 'guardian check' and no
+// baseline, and returns the violations found.
+func scanViolations(agreementsDir string) ([]engine.Violation, error) {
+	constitution, err := loadConstitutionFrom(agreementsDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading constitution: %w", err)
+	}
+
+	rulesFile, err := loadRulesFrom(agreementsDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading rules: %w", err)
+	}
+
+	exceptions, err := loadAllExceptionsFrom(agreementsDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading exceptions: %w", err)
+	}
+
+	exceptionValues := make([]config.Exception, 0, len(exceptions))
+	for _, e := range exceptions {
+		if e != nil {
+			exceptionValues = append(exceptionValues, *e)
+		}
+	}
+
+	diffResult, err := git.GetTreeDiff("HEAD", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting diff: %w", err)
+	}
+
+	eng := engine.NewEngine(rulesFile.Rules, exceptionValues)
+	eng.SuppressionPolicy = constitution.Governance.InlineSuppressions
+	eng.Repo = git.NewRepoReader(git.Unavailable("a repository scan has no base revision"), git.AtRevision("HEAD"))
+	eng.RepoRoot = repoRoot(agreementsDir)
+
+	result, err := eng.Run(context.Background(), diffResult.ChangedFiles, diffResult.DiffContent)
+	if err != nil {
+		return nil, fmt.Errorf("running checks: %w", err)
+	}
+
+	// Violations of a rule that fails to run cannot be recorded, so they will
+	// show up as new once the rule is fixed.
+	for _, re := range result.RuleErrors {
+		fmt.Fprintf(os.Stderr, "Warning: rule %q failed to run: %s\n", re.RuleID, re.Message)
+	}
+
+	return result.Violations, nil
+}
+
+// sortBaselineEntries orders entries by path, rule ID and fingerprint so that
+// the baseline file diffs cleanly.
+func sortBaselineEntries(entries []config.BaselineEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+}
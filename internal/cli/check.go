@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -14,7 +16,9 @@ import (
 	"github.com/AlexGladkov/guardian-cli/internal/output"
 )
 
-const checkUsage = `Usage: guardian check [base..head] [--json]
+const checkUsage = `Usage: guardian check [base..head] [--tags <tags>] [--fix[=patch] [--force]] [--json]
+       guardian check --staged | --worktree [--tags <tags>] [--fix[=patch] [--force]] [--json]
+       guardian check --all [--paths <globs>] [--tags <tags>] [--fix[=patch] [--force]] [--json]
 
 Check code changes against configured rules.
 
@@ -23,27 +27,119 @@ The diff range is determined by (in priority order):
   2. CI auto-detection (GitHub Actions, GitLab CI)
   3. Default: origin/main..HEAD
 
+With --staged, the changes in the index (what the next commit would contain)
+are checked; with --worktree, all uncommitted changes relative to HEAD.
+
+For a commit range, the commits themselves are checked too: commit_message
+rules and the constitution's identity settings (allowed email domains,
+signed commits) apply to each of them.
+
+With --all or --paths, the whole repository is scanned instead: every file
+tracked at HEAD is checked as if it were newly added. The .agreements/ meta
+check and LLM analysis are skipped in this mode.
+
+With --fix, violations of rules that declare a fix are fixed in the working
+tree, and a summary of the changed lines follows the report. Only the lines
+the checked changes add are rewritten. Files with changes that were not
+checked are not touched unless --force is given: uncommitted changes for a
+range or scan, and unstaged changes for --staged (stage the fixes after).
+With --fix=patch, the fixes are printed
+to stdout as a unified diff (e.g., for git apply) and the working tree is
+left alone; the summary goes to stderr.
+
 Flags:
-  --json     Output results as JSON
-  --help     Show this help message
+  --staged          Check staged changes instead of a commit range
+  --worktree        Check uncommitted working-tree changes instead of a commit range
+  --all             Scan all tracked files at HEAD instead of a diff
+  --paths <globs>   Scan only tracked files matching the comma-separated globs
+                    (e.g., "domain/**,app/**"); implies --all
+  --tags <tags>     Run only the rules with at least one of the comma-separated
+                    tags (e.g., "security,api")
+  --fix             Apply rule fixes to the working tree
+  --fix=patch       Print rule fixes as a unified diff instead of applying them
+  --force           With --fix, also fix files that have unchecked changes
+  --json            Output results as JSON
+  --help            Show this help message
 
 Exit codes:
-  0  All checks passed
+  0  All checks passed (with --fix=patch: the patch was printed)
   1  Violations found
-  2  Error occurred
+  2  Error occurred (or a rule failed to run and check.fail_on_rule_error is set)
 `
 
+// Modes of guardian check --fix.
+const (
+	fixApply = "apply"
+	fixPatch = "patch"
+)
+
+// fixFlag is the value of the --fix flag: empty if fixes are not requested,
+// fixApply for a bare --fix and fixPatch for --fix=patch.
+type fixFlag string
+
+func (f *fixFlag) String() string { return string(*f) }
+
+func (f *fixFlag) Set(value string) error {
+	switch value {
+	case "true", fixApply:
+		*f = fixApply
+	case fixPatch:
+		*f = fixPatch
+	case "false":
+		*f = ""
+	default:
+		return fmt.Errorf("must be %s or %s", fixApply, fixPatch)
+	}
+	return nil
+}
+
+// IsBoolFlag lets --fix be given without a value.
+func (f *fixFlag) IsBoolFlag() bool { return true }
+
 func runCheck(args []string) int {
 	fs := flag.NewFlagSet("check", flag.ContinueOnError)
 	jsonOutput := fs.Bool("json", false, "Output results as JSON")
+	scanAll := fs.Bool("all", false, "Scan all tracked files at HEAD")
+	scanPaths := fs.String("paths", "", "Scan only tracked files matching these comma-separated globs")
+	staged := fs.Bool("staged", false, "Check staged changes")
+	worktree := fs.Bool("worktree", false, "Check uncommitted working-tree changes")
+	tags := fs.String("tags", "", "Run only rules with one of these comma-separated tags")
+	var fix fixFlag
+	fs.Var(&fix, "fix", "Apply rule fixes (or print them with --fix=patch)")
+	force := fs.Bool("force", false, "With --fix, also fix files with unchecked changes")
 	fs.Usage = func() { fmt.Fprint(os.Stderr, checkUsage) }
 
 	if err := fs.Parse(reorderArgs(args)); err != nil {
 		return 2
 	}
 
+	// Scan mode checks the full content of tracked files instead of a diff.
+	scanMode := *scanAll || *scanPaths != ""
+
+	modes := 0
+	for _, set := range []bool{scanMode, *staged, *worktree, len(fs.Args()) > 0} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		fmt.Fprintln(os.Stderr, "Error: a diff range, --staged, --worktree and --all/--paths are mutually exclusive")
+		return 2
+	}
+	if fix == fixPatch && *jsonOutput {
+		fmt.Fprintln(os.Stderr, "Error: --fix=patch and --json are mutually exclusive")
+		return 2
+	}
+
+	// Ctrl-C cancels running rules and in-flight LLM calls.
+	ctx, stop := interruptContext()
+	defer stop()
+
 	// Determine diff range.
-	diffRange := determineDiffRange(fs.Args())
+	diffRange := "HEAD"
+	if !scanMode && !*staged && !*worktree {
+		diffRange = determineDiffRange(fs.Args())
+	}
 
 	// Find .agreements directory.
 	agreementsDir, err := findAgreementsDir()
@@ -66,6 +162,16 @@ func runCheck(args []string) int {
 		return 2
 	}
 
+	// --tags limits the check to a subset of the rules.
+	checkedRules := rulesFile.Rules
+	if *tags != "" {
+		checkedRules = rulesWithTags(rulesFile.Rules, splitAndTrim(*tags, ","))
+		if len(checkedRules) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no rules are tagged %s\n", *tags)
+			return 2
+		}
+	}
+
 	// Load exceptions.
 	exceptions, err := loadAllExceptionsFrom(agreementsDir)
 	if err != nil {
@@ -73,6 +179,13 @@ func runCheck(args []string) int {
 		return 2
 	}
 
+	// Load baseline (empty if the file doesn't exist).
+	baseline, err := loadBaselineFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading baseline: %v\n", err)
+		return 2
+	}
+
 	// Load proposals (non-fatal if directory doesn't exist).
 	proposals, err := loadAllProposalsFrom(agreementsDir)
 	if err != nil {
@@ -88,8 +201,19 @@ func runCheck(args []string) int {
 		}
 	}
 
-	// Get diff.
-	diffResult, err := git.GetDiff(diffRange)
+	// Get diff. In scan mode, every tracked file is diffed against the empty
+	// tree so that checkers see its full content as added lines.
+	var diffResult *git.DiffResult
+	switch {
+	case scanMode:
+		diffResult, err = git.GetTreeDiff(diffRange, splitAndTrim(*scanPaths, ","))
+	case *staged:
+		diffResult, err = git.GetStagedDiff()
+	case *worktree:
+		diffResult, err = git.GetWorktreeDiff()
+	default:
+		diffResult, err = git.GetDiff(diffRange)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: getting diff: %v\n", err)
 		return 2
@@ -97,25 +221,127 @@ func runCheck(args []string) int {
 
 	// Handle empty diff.
 	if len(diffResult.ChangedFiles) == 0 {
+		switch {
+		case scanMode:
+			fmt.Fprintln(os.Stdout, "No tracked files match.")
+			return 0
+		case *staged:
+			fmt.Fprintln(os.Stdout, "No staged changes found.")
+			return 0
+		case *worktree:
+			fmt.Fprintln(os.Stdout, "No uncommitted changes found.")
+			return 0
+		}
 		fmt.Fprintln(os.Stdout, "No changes found. Try specifying a range: guardian check HEAD~3..HEAD")
 		return 0
 	}
 
 	// Run engine checks.
-	eng := engine.NewEngine(rulesFile.Rules, exceptionValues)
-	engineResult, err := eng.Run(diffResult.ChangedFiles, diffResult.DiffContent)
+	eng := engine.NewEngine(checkedRules, exceptionValues)
+	eng.Baseline = baseline.Entries
+	eng.SuppressionPolicy = constitution.Governance.InlineSuppressions
+	eng.Identity = constitution.Identity
+	root := repoRoot(agreementsDir)
+	eng.RepoRoot = root
+	switch {
+	case scanMode:
+		eng.Repo = git.NewRepoReader(git.Unavailable("a repository scan has no base revision"), git.AtRevision(diffRange))
+	case *staged:
+		// Staged content is read from the index.
+		eng.Repo = git.NewRepoReader(git.AtRevision("HEAD"), git.AtRevision(""))
+	case *worktree:
+		eng.Repo = git.NewRepoReader(git.AtRevision("HEAD"), git.FromWorktree(root))
+	default:
+		repo, err := rangeRepoReader(root, diffRange)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		eng.Repo = repo
+
+		// Only a commit range has commits to check.
+		if engine.NeedsCommits(checkedRules, constitution.Identity) {
+			commits, err := git.ListCommits(commitRange(diffRange), constitution.Identity.RequireSignedCommits)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: listing commits: %v\n", err)
+				return 2
+			}
+			eng.Commits = commits
+		}
+	}
+	engineResult, err := eng.Run(ctx, diffResult.ChangedFiles, diffResult.DiffContent)
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Error: check interrupted")
+		return 2
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: running checks: %v\n", err)
 		return 2
 	}
 
-	// Run meta check: look for protected .agreements/ file changes.
-	metaChecker := &engine.MetaChecker{}
-	proposalsDir := filepath.Join(agreementsDir, "proposals")
-	metaViolations, err := metaChecker.Check(diffResult.ChangedFiles, proposalsDir)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: running meta check: %v\n", err)
-		return 2
+	// Fix the violations that remain after exceptions, suppressions and the
+	// baseline. A patch is the only output of --fix=patch.
+	var fixReport *output.FixReport
+	if fix != "" {
+		plan := engine.PlanFixes(checkedRules, engineResult.Violations, engine.NewParsedDiff(diffResult.DiffContent), func(p string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		})
+		if fix == fixPatch {
+			fmt.Fprint(os.Stdout, plan.Patch())
+			output.PrintFixReportHuman(os.Stderr, buildFixReport(plan, false, rulesFile.Rules))
+			return 0
+		}
+
+		// The fixed lines are uncommitted in --staged and --worktree checks,
+		// so only changes beyond the checked content are refused: unstaged
+		// changes for --staged, none for --worktree, which checks the
+		// working tree itself.
+		if !*force && !*worktree {
+			var dirty []string
+			var err error
+			if *staged {
+				dirty, err = git.UnstagedFiles(root, plan.Paths())
+			} else {
+				dirty, err = git.DirtyFiles(root, plan.Paths())
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 2
+			}
+			if len(dirty) > 0 {
+				fmt.Fprintf(os.Stderr, "Error: refusing to fix files with changes that were not checked: %s\n", strings.Join(dirty, ", "))
+				fmt.Fprintln(os.Stderr, "Commit, stage or stash them, use --fix=patch, or pass --force.")
+				return 2
+			}
+		}
+		if err := plan.Apply(root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		fixReport = buildFixReport(plan, true, rulesFile.Rules)
+	}
+
+	// Run meta check: look for protected .agreements/ file changes. A scan
+	// has no changes to protect, so the check only runs on diffs.
+	var metaViolations []engine.Violation
+	if !scanMode {
+		metaChecker := &engine.MetaChecker{Protected: governedPaths(root, rulesFile)}
+		proposalsDir := filepath.Join(agreementsDir, "proposals")
+		metaViolations, err = metaChecker.Check(diffResult.ChangedFiles, proposalsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: running meta check: %v\n", err)
+			return 2
+		}
+	}
+
+	// A change to the rules must keep every rule's examples passing.
+	if !scanMode && rulesChanged(root, rulesFile, diffResult.ChangedFiles) {
+		exampleViolations, err := ruleExampleViolations(ctx, rulesFile.Rules, root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: check interrupted")
+			return 2
+		}
+		metaViolations = append(metaViolations, exampleViolations...)
 	}
 
 	// Merge meta violations into engine result.
@@ -135,14 +361,41 @@ func runCheck(args []string) int {
 		engineResult.Warnings++
 	}
 
-	// Try LLM analysis for explanations (non-fatal if unavailable).
-	llmExplanations := tryLLMAnalysis(constitution, rulesFile, diffResult.DiffContent, allViolations, proposals)
+	// The engine redacts secrets found by the rules it ran; redact with all
+	// rules in case --tags left out the secrets_forbidden rules.
+	for i := range allViolations {
+		allViolations[i].DiffSnippet = engine.RedactSecrets(rulesFile.Rules, allViolations[i].DiffSnippet)
+	}
+
+	// Try LLM analysis for explanations (non-fatal if unavailable). A scan
+	// diff is the whole repository, which is too large to send. Secrets
+	// found by any secrets_forbidden rule are redacted before sending.
+	var llmExplanations map[string]string
+	if !scanMode {
+		llmDiff := engine.RedactSecrets(rulesFile.Rules, diffResult.DiffContent)
+		llmExplanations = tryLLMAnalysis(ctx, constitution, checkedRules, llmDiff, allViolations, proposals)
+	}
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Error: check interrupted")
+		return 2
+	}
 
 	// Build proposal context for the report.
 	proposalCtx := buildProposalContext(proposals)
 
 	// Build report.
 	report := buildCheckReport(allViolations, engineResult.Errors, engineResult.Warnings, llmExplanations, proposalCtx)
+	report.Baselined = buildViolationReports(engineResult.Baselined)
+	report.Summary.Baselined = len(report.Baselined)
+	report.Suppressions, report.Summary.Suppressed = buildSuppressionReports(engineResult.Suppressions)
+	report.RuleErrors = buildRuleErrorReports(engineResult.RuleErrors)
+	report.Fixes = fixReport
+
+	// Rule errors fail the run only if the constitution says so.
+	failOnRuleErrors := len(engineResult.RuleErrors) > 0 && constitution.Check.FailOnRuleError
+	if failOnRuleErrors {
+		report.Summary.Passed = false
+	}
 
 	// Output report.
 	if *jsonOutput {
@@ -155,12 +408,90 @@ func runCheck(args []string) int {
 	}
 
 	// Exit code based on results.
+	if failOnRuleErrors {
+		return 2
+	}
 	if engineResult.Errors > 0 {
 		return 1
 	}
 	return 0
 }
 
+// governedPaths returns the repository-relative files, besides rules.yml and
+// constitution.yml, whose changes require an accepted proposal: the scripts
+// run by command rules and the rules files extended from inside the
+// repository.
+func governedPaths(root string, rulesFile *config.RulesFile) []string {
+	paths := engine.CommandRulePaths(rulesFile.Rules)
+	for _, f := range rulesFile.Files {
+		rel, err := filepath.Rel(root, f)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue // outside the repository
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	return paths
+}
+
+// rulesChanged reports whether the diff changes rules.yml or a rules file it
+// extends from inside the repository.
+func rulesChanged(root string, rulesFile *config.RulesFile, changedFiles []string) bool {
+	rulesPaths := make(map[string]bool, len(rulesFile.Files))
+	for _, f := range rulesFile.Files {
+		if rel, err := filepath.Rel(root, f); err == nil {
+			rulesPaths[filepath.ToSlash(rel)] = true
+		}
+	}
+	for _, file := range changedFiles {
+		if rulesPaths[filepath.ToSlash(file)] {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleExampleViolations runs the examples of all rules and reports each
+// failing example as an error. It returns an error only if ctx is canceled.
+func ruleExampleViolations(ctx context.Context, rules []config.Rule, root string) ([]engine.Violation, error) {
+	var violations []engine.Violation
+	for _, rule := range rules {
+		results, err := engine.RunExamples(ctx, rule, root)
+		if err != nil {
+			return nil, err
+		}
+		// Report the failure against the file defining the rule, unless the
+		// rule comes from another repository.
+		file := ".agreements/rules.yml"
+		if rule.Source != "" && !strings.Contains(rule.Source, "@") {
+			file = path.Join(".agreements", rule.Source)
+		}
+		for _, res := range results {
+			if res.Passed() {
+				continue
+			}
+			violations = append(violations, engine.Violation{
+				RuleID:   "rule_examples",
+				Severity: "error",
+				Description: fmt.Sprintf("Rule %q %s failed: %s. Run 'guardian rules test %s' for details.",
+					rule.ID, res.Label(), res.Failure(), rule.ID),
+				FilePath: file,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// rulesWithTags returns the rules that have at least one of the given tags.
+func rulesWithTags(rules []config.Rule, tags []string) []config.Rule {
+	var result []config.Rule
+	for _, r := range rules {
+		if r.HasAnyTag(tags) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
 // determineDiffRange resolves the diff range from arguments, CI, or default.
 func determineDiffRange(positionalArgs []string) string {
 	// 1. Explicit argument.
@@ -182,11 +513,58 @@ func determineDiffRange(positionalArgs []string) string {
 	return "origin/main..HEAD"
 }
 
+// rangeRepoReader returns a RepoReader for the base and head revisions of a
+// diff range, following git diff semantics: "a..b" compares a with b,
+// "a...b" compares the merge base of a and b with b, an omitted side means
+// HEAD, and a single revision (e.g., "main") compares it with the working
+// tree, so head files are read from disk.
+func rangeRepoReader(root, diffRange string) (*git.RepoReader, error) {
+	if i := strings.Index(diffRange, "..."); i >= 0 {
+		head := revOrHEAD(diffRange[i+3:])
+		base, err := git.MergeBase(revOrHEAD(diffRange[:i]), head)
+		if err != nil {
+			return nil, err
+		}
+		return git.NewRepoReader(git.AtRevision(base), git.AtRevision(head)), nil
+	}
+
+	if i := strings.Index(diffRange, ".."); i >= 0 {
+		base := revOrHEAD(diffRange[:i])
+		head := revOrHEAD(diffRange[i+2:])
+		return git.NewRepoReader(git.AtRevision(base), git.AtRevision(head)), nil
+	}
+
+	return git.NewRepoReader(git.AtRevision(diffRange), git.FromWorktree(root)), nil
+}
+
+// commitRange returns the git log range of the commits that make up a diff
+// range: "a..b" and "a...b" both list the commits reachable from b but not
+// from a, and a single revision lists those between it and HEAD.
+func commitRange(diffRange string) string {
+	if i := strings.Index(diffRange, "..."); i >= 0 {
+		return diffRange[:i] + ".." + diffRange[i+3:]
+	}
+	if strings.Contains(diffRange, "..") {
+		return diffRange
+	}
+	return diffRange + "..HEAD"
+}
+
+// revOrHEAD returns rev, or "HEAD" if rev is empty.
+func revOrHEAD(rev string) string {
+	if rev == "" {
+		return "HEAD"
+	}
+	return rev
+}
+
 // tryLLMAnalysis attempts to get LLM explanations for violations.
-// Returns a map of rule_id -> explanation. Returns nil on any error.
+// Returns a map of rule_id -> explanation. Returns nil on any error, or if
+// ctx is canceled.
 func tryLLMAnalysis(
+	ctx context.Context,
 	constitution *config.Constitution,
-	rulesFile *config.RulesFile,
+	rules []config.Rule,
 	diffContent string,
 	violations []engine.Violation,
 	proposals []*config.Proposal,
@@ -213,12 +591,16 @@ func tryLLMAnalysis(
 			Severity:    v.Severity,
 			Description: v.Description,
 			FilePath:    v.FilePath,
+			Line:        v.Line,
 			DiffSnippet: v.DiffSnippet,
 		})
 	}
 
-	analysis, err := client.AnalyzeCheck(diffContent, rulesFile.Rules, llmViolations, proposals)
+	analysis, err := client.AnalyzeCheck(ctx, diffContent, rules, llmViolations, proposals)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
 		fmt.Fprintf(os.Stderr, "Warning: LLM analysis failed: %v\n", err)
 		return nil
 	}
@@ -249,6 +631,9 @@ func buildCheckReport(
 			Severity:    v.Severity,
 			Description: v.Description,
 			FilePath:    v.FilePath,
+			Line:        v.Line,
+			EndLine:     v.EndLine,
+			Commit:      v.Commit,
 			DiffSnippet: v.DiffSnippet,
 		}
 
@@ -270,6 +655,88 @@ func buildCheckReport(
 	return report
 }
 
+// buildViolationReports converts violations into output reports without LLM
+// explanations, e.g., for baselined violations.
+func buildViolationReports(violations []engine.Violation) []output.ViolationReport {
+	var reports []output.ViolationReport
+	for _, v := range violations {
+		reports = append(reports, output.ViolationReport{
+			RuleID:      v.RuleID,
+			Severity:    v.Severity,
+			Description: v.Description,
+			FilePath:    v.FilePath,
+			Line:        v.Line,
+			EndLine:     v.EndLine,
+			Commit:      v.Commit,
+			DiffSnippet: v.DiffSnippet,
+		})
+	}
+	return reports
+}
+
+// buildSuppressionReports converts inline suppressions into output reports
+// and counts the ones that were applied.
+func buildSuppressionReports(suppressions []engine.Suppression) ([]output.SuppressionReport, int) {
+	var reports []output.SuppressionReport
+	applied := 0
+	for _, s := range suppressions {
+		if s.Rejected == "" {
+			applied++
+		}
+		reports = append(reports, output.SuppressionReport{
+			RuleID:      s.Violation.RuleID,
+			Severity:    s.Violation.Severity,
+			FilePath:    s.Violation.FilePath,
+			Line:        s.Violation.Line,
+			CommentLine: s.Line,
+			Reason:      s.Reason,
+			Rejected:    s.Rejected,
+		})
+	}
+	return reports, applied
+}
+
+// buildFixReport summarizes a fix plan; applied tells whether it was written
+// to the working tree. Secrets that the secrets_forbidden rules among rules
+// find in the old and new lines are redacted, as in violation snippets.
+func buildFixReport(plan *engine.FixPlan, applied bool, rules []config.Rule) *output.FixReport {
+	report := &output.FixReport{Applied: applied, Files: len(plan.Files), Lines: []output.FixedLineReport{}}
+	for _, f := range plan.Files {
+		for _, l := range f.Lines {
+			report.Lines = append(report.Lines, output.FixedLineReport{
+				RuleID:   l.RuleID,
+				FilePath: f.Path,
+				Line:     l.Line,
+				Old:      engine.RedactSecrets(rules, l.Old),
+				New:      engine.RedactSecrets(rules, l.New),
+			})
+		}
+	}
+	for _, s := range plan.Skipped {
+		report.Skipped = append(report.Skipped, output.SkippedFixReport{
+			RuleID:   s.Violation.RuleID,
+			Severity: s.Violation.Severity,
+			FilePath: s.Violation.FilePath,
+			Line:     s.Violation.Line,
+			Reason:   s.Reason,
+		})
+	}
+	return report
+}
+
+// buildRuleErrorReports converts engine rule errors into output reports.
+func buildRuleErrorReports(ruleErrors []engine.RuleError) []output.RuleErrorReport {
+	var reports []output.RuleErrorReport
+	for _, e := range ruleErrors {
+		reports = append(reports, output.RuleErrorReport{
+			RuleID:   e.RuleID,
+			RuleType: e.RuleType,
+			Error:    e.Message,
+		})
+	}
+	return reports
+}
+
 // buildProposalContext creates proposal context from loaded proposals.
 // Returns nil if there are no relevant proposals.
 func buildProposalContext(proposals []*config.Proposal) *output.ProposalContext {
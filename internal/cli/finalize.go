@@ -19,6 +19,10 @@ The proposal must have achieved ACCEPTED status via tally before it
 can be finalized. Finalization updates the proposal status to "accepted"
 and creates a history record.
 
+If the constitution sets identity.allowed_domains or
+identity.require_signed_commits, every commit that added or changed the
+proposal or one of its votes must satisfy them.
+
 Arguments:
   proposal_id    The ID of the proposal to finalize
 
@@ -27,7 +31,7 @@ Flags:
 
 Exit codes:
   0  Proposal finalized successfully
-  1  Proposal not accepted (cannot finalize)
+  1  Proposal not accepted, or committed by a disallowed identity
   2  Error occurred
 `
 
@@ -93,6 +97,19 @@ func runFinalize(args []string) int {
 		return 1
 	}
 
+	// The proposal and its votes must have been committed by identities
+	// that satisfy the constitution.
+	identityViolations, err := proposalIdentityViolations(constitution, agreementsDir, proposalPath, proposalID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: checking identities: %v\n", err)
+		return 2
+	}
+	if len(identityViolations) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: commits of proposal %q break the identity settings:\n", proposalID)
+		printIdentityViolations(os.Stderr, identityViolations)
+		return 1
+	}
+
 	// Update proposal status.
 	proposal.Status = "accepted"
 	if err := saveProposalAtPath(proposalPath, proposal); err != nil {
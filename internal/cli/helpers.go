@@ -2,13 +2,19 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/AlexGladkov/guardian-cli/internal/config"
 	"github.com/AlexGladkov/guardian-cli/internal/discovery"
+	"github.com/AlexGladkov/guardian-cli/internal/engine"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
 	"gopkg.in/yaml.v3"
 )
 
@@ -27,10 +33,11 @@ func loadConstitutionFrom(agreementsDir string) (*config.Constitution, error) {
 	return config.LoadConstitution(path)
 }
 
-// loadRulesFrom loads the rules from the agreements directory.
+// loadRulesFrom loads the effective rules from the agreements directory,
+// resolving extends.
 func loadRulesFrom(agreementsDir string) (*config.RulesFile, error) {
 	path := filepath.Join(agreementsDir, "rules.yml")
-	return config.LoadRules(path)
+	return config.ResolveRules(path)
 }
 
 // loadAllProposalsFrom loads all proposals from the agreements directory.
@@ -45,6 +52,13 @@ func loadAllExceptionsFrom(agreementsDir string) ([]*config.Exception, error) {
 	return config.LoadAllExceptions(dir)
 }
 
+// loadBaselineFrom loads the baseline from the agreements directory. A missing
+// baseline file yields an empty baseline.
+func loadBaselineFrom(agreementsDir string) (*config.Baseline, error) {
+	path := filepath.Join(agreementsDir, "baseline.yml")
+	return config.LoadBaseline(path)
+}
+
 // loadVotesForProposalFrom loads all votes for a proposal from the agreements directory.
 func loadVotesForProposalFrom(agreementsDir string, proposalID string) ([]*config.Vote, error) {
 	dir := filepath.Join(agreementsDir, "votes")
@@ -80,6 +94,13 @@ func findProposalByID(agreementsDir string, proposalID string) (*config.Proposal
 	return nil, "", fmt.Errorf("proposal %q not found", proposalID)
 }
 
+// interruptContext returns a context that is canceled on Ctrl-C or SIGTERM,
+// so that long-running work (rule checks, LLM calls) can stop cleanly. Call
+// stop to restore the default signal handling.
+func interruptContext() (ctx context.Context, stop context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
 // marshalToYAML marshals a value to YAML bytes.
 func marshalToYAML(v interface{}) ([]byte, error) {
 	return yaml.Marshal(v)
@@ -134,7 +155,9 @@ func reorderArgs(args []string) []string {
 				name := strings.TrimLeft(args[i], "-")
 				if name != "yes" && name != "no" && name != "help" &&
 					name != "json" && name != "llm" && name != "force" &&
-					name != "notify" && name != "since-last-check" && name != "quiet" && name != "no-fetch" {
+					name != "notify" && name != "since-last-check" && name != "quiet" && name != "no-fetch" &&
+					name != "all" && name != "staged" && name != "worktree" &&
+					name != "pre-commit" && name != "pre-push" && name != "fix" {
 					i++
 					flags = append(flags, args[i])
 				}
@@ -152,6 +175,34 @@ func repoRoot(agreementsDir string) string {
 	return filepath.Dir(agreementsDir)
 }
 
+// proposalIdentityViolations applies the constitution's identity settings to
+// the commits that added or changed a proposal's file and its vote files.
+// Files that are not committed yet have no commits to check.
+func proposalIdentityViolations(constitution *config.Constitution, agreementsDir, proposalPath, proposalID string) ([]engine.Violation, error) {
+	if !constitution.Identity.HasChecks() {
+		return nil, nil
+	}
+
+	commits, err := git.ListCommits("HEAD", constitution.Identity.RequireSignedCommits, proposalPath, filepath.Join(agreementsDir, "votes", proposalID))
+	if err != nil {
+		return nil, fmt.Errorf("listing the commits of proposal %s: %w", proposalID, err)
+	}
+	checker := &engine.IdentityChecker{Identity: constitution.Identity}
+	return checker.Check(commits), nil
+}
+
+// printIdentityViolations prints one line per identity violation, naming the
+// commit by its abbreviated SHA.
+func printIdentityViolations(w io.Writer, violations []engine.Violation) {
+	for _, v := range violations {
+		sha := v.Commit
+		if len(sha) > 12 {
+			sha = sha[:12]
+		}
+		fmt.Fprintf(w, "  %s %s (%s)\n", sha, v.Description, v.DiffSnippet)
+	}
+}
+
 // printGitHint prints a hint to add and commit files.
 func printGitHint(paths ...string) {
 	fmt.Fprintln(os.Stdout, "")
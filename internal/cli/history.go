@@ -38,21 +38,40 @@ func runHistory(args []string) int {
 		return 0
 	}
 
-	// Read history directory.
+	items, err := loadHistoryItemsFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 0
+	}
+
+	// Build history report.
+	report := &output.HistoryReport{Items: items}
+
+	// Output.
+	if *jsonOutput {
+		if err := output.PrintHistoryReportJSON(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing JSON output: %v\n", err)
+		}
+	} else {
+		output.PrintHistoryReportHuman(os.Stdout, report)
+	}
+
+	return 0
+}
+
+// loadHistoryItemsFrom reads the finalized proposals recorded in the history
+// directory. A missing directory means no history.
+func loadHistoryItemsFrom(agreementsDir string) ([]output.HistoryItem, error) {
 	historyDir := filepath.Join(agreementsDir, "history")
 	entries, err := os.ReadDir(historyDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			entries = nil
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: reading history directory: %v\n", err)
-			return 0
+			return nil, nil
 		}
+		return nil, fmt.Errorf("reading history directory: %w", err)
 	}
 
-	// Build history report.
-	report := &output.HistoryReport{}
-
+	var items []output.HistoryItem
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -68,20 +87,9 @@ func runHistory(args []string) int {
 			continue
 		}
 
-		item := parseHistoryFile(entry.Name(), string(data))
-		report.Items = append(report.Items, item)
-	}
-
-	// Output.
-	if *jsonOutput {
-		if err := output.PrintHistoryReportJSON(os.Stdout, report); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: writing JSON output: %v\n", err)
-		}
-	} else {
-		output.PrintHistoryReportHuman(os.Stdout, report)
+		items = append(items, parseHistoryFile(entry.Name(), string(data)))
 	}
-
-	return 0
+	return items, nil
 }
 
 // parseHistoryFile extracts a HistoryItem from a markdown history file.
@@ -4,21 +4,28 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/AlexGladkov/guardian-cli/internal/git"
 )
 
-const hooksUsage = `Usage: guardian hooks <install|uninstall>
+const hooksUsage = `Usage: guardian hooks <install|uninstall> [--pre-commit] [--pre-push]
 
 Manage Guardian git hooks. Installs or uninstalls post-merge and
 post-checkout hooks that trigger 'guardian inbox --notify'.
 
+Optionally, install also adds hooks that run 'guardian check' and block
+on errors: pre-commit checks the staged changes ('guardian check --staged'),
+pre-push checks the commits being pushed.
+
 Subcommands:
   install      Install guardian git hooks
-  uninstall    Uninstall guardian git hooks
+  uninstall    Uninstall all guardian git hooks
 
 Flags:
-  --help     Show this help message
+  --pre-commit   Also install a pre-commit hook running 'guardian check --staged'
+  --pre-push     Also install a pre-push hook running 'guardian check' on pushed commits
+  --help         Show this help message
 
 Exit codes:
   0  Success
@@ -27,9 +34,11 @@ Exit codes:
 
 func runHooks(args []string) int {
 	fs := flag.NewFlagSet("hooks", flag.ContinueOnError)
+	preCommit := fs.Bool("pre-commit", false, "Also install a pre-commit check hook")
+	prePush := fs.Bool("pre-push", false, "Also install a pre-push check hook")
 	fs.Usage = func() { fmt.Fprint(os.Stderr, hooksUsage) }
 
-	if err := fs.Parse(args); err != nil {
+	if err := fs.Parse(reorderArgs(args)); err != nil {
 		return 2
 	}
 
@@ -44,11 +53,20 @@ func runHooks(args []string) int {
 
 	switch subcommand {
 	case "install":
-		if err := git.InstallHooks(); err != nil {
+		var checkHooks []string
+		if *preCommit {
+			checkHooks = append(checkHooks, "pre-commit")
+		}
+		if *prePush {
+			checkHooks = append(checkHooks, "pre-push")
+		}
+
+		if err := git.InstallHooks(checkHooks...); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: installing hooks: %v\n", err)
 			return 2
 		}
-		fmt.Fprintln(os.Stdout, "Guardian hooks installed (post-merge, post-checkout).")
+		installed := append([]string{"post-merge", "post-checkout"}, checkHooks...)
+		fmt.Fprintf(os.Stdout, "Guardian hooks installed (%s).\n", strings.Join(installed, ", "))
 		return 0
 
 	case "uninstall":
@@ -119,10 +119,12 @@ func runPropose(args []string) int {
 			return 2
 		}
 
-		context, _ := promptLine("Additional context for the LLM (optional): ")
+		llmContext, _ := promptLine("Additional context for the LLM (optional): ")
 
 		fmt.Fprintln(os.Stdout, "Generating proposal draft with LLM...")
-		draft, draftErr := client.DraftProposal(*targetRule, context)
+		ctx, stop := interruptContext()
+		draft, draftErr := client.DraftProposal(ctx, *targetRule, llmContext)
+		stop()
 		if draftErr != nil {
 			fmt.Fprintf(os.Stderr, "Error: LLM draft failed: %v\n", draftErr)
 			return 2
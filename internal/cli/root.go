@@ -27,6 +27,8 @@ Commands:
   hooks            Install or uninstall git hooks
   history          Show finalized proposal history
   exception        Manage rule exceptions
+  baseline         Record or prune known violations
+  rules            Inspect the effective rule set
   constitution     Show current constitution
   llm              LLM configuration management
 
@@ -78,6 +80,10 @@ func Run(args []string) int {
 		return runHistory(commandArgs)
 	case "exception":
 		return runException(commandArgs)
+	case "baseline":
+		return runBaseline(commandArgs)
+	case "rules":
+		return runRules(commandArgs)
 	case "constitution":
 		return runConstitution(commandArgs)
 	case "llm":
@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/engine"
+	"github.com/AlexGladkov/guardian-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+const rulesUsage = `Usage: guardian rules <list|show|test|resolved>
+
+Inspect the rules in .agreements/rules.yml.
+
+Subcommands:
+  list [--json]            List the rules with their severity, owner and tags
+  show <rule_id> [--json]  Show a rule with its active exceptions, open
+                           proposals and finalized history
+  test [rule_id...] [--json]
+                           Run the examples of all rules (or of the given
+                           rules) through their checkers
+  resolved                 Print the effective rule set after resolving
+                           extends and overrides, with the file each rule
+                           comes from
+
+Flags:
+  --json     Output results as JSON (list, show, test)
+  --help     Show this help message
+
+Exit codes:
+  0  Success
+  1  A rule example failed (test)
+  2  Error occurred
+`
+
+func runRules(args []string) int {
+	fs := flag.NewFlagSet("rules", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, rulesUsage) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Error: subcommand required (list, show, test, resolved)")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprint(os.Stderr, rulesUsage)
+		return 2
+	}
+
+	subcommand := fs.Arg(0)
+
+	switch subcommand {
+	case "list":
+		return runRulesList(fs.Args()[1:])
+	case "show":
+		return runRulesShow(fs.Args()[1:])
+	case "test":
+		return runRulesTest(fs.Args()[1:])
+	case "resolved":
+		return runRulesResolved()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown rules subcommand %q; use list, show, test or resolved\n", subcommand)
+		return 2
+	}
+}
+
+func runRulesList(args []string) int {
+	fs := flag.NewFlagSet("rules list", flag.ContinueOnError)
+	jsonOutput := fs.Bool("json", false, "Output results as JSON")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, rulesUsage) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	rulesFile, err := loadRulesFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading rules: %v\n", err)
+		return 2
+	}
+
+	report := &output.RulesReport{Rules: []output.RuleSummary{}}
+	for _, r := range rulesFile.Rules {
+		report.Rules = append(report.Rules, ruleSummary(r))
+	}
+
+	if *jsonOutput {
+		if err := output.PrintRulesReportJSON(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing JSON output: %v\n", err)
+			return 2
+		}
+	} else {
+		output.PrintRulesReportHuman(os.Stdout, report)
+	}
+	return 0
+}
+
+func runRulesShow(args []string) int {
+	fs := flag.NewFlagSet("rules show", flag.ContinueOnError)
+	jsonOutput := fs.Bool("json", false, "Output results as JSON")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, rulesUsage) }
+
+	if err := fs.Parse(reorderArgs(args)); err != nil {
+		return 2
+	}
+
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "Error: rule_id argument is required")
+		fmt.Fprintln(os.Stderr, "Usage: guardian rules show <rule_id> [--json]")
+		return 2
+	}
+	ruleID := fs.Arg(0)
+
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	constitution, err := loadConstitutionFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading constitution: %v\n", err)
+		return 2
+	}
+
+	rulesFile, err := loadRulesFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading rules: %v\n", err)
+		return 2
+	}
+
+	var rule *config.Rule
+	for i := range rulesFile.Rules {
+		if rulesFile.Rules[i].ID == ruleID {
+			rule = &rulesFile.Rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		fmt.Fprintf(os.Stderr, "Error: rule %q not found in rules.yml\n", ruleID)
+		return 2
+	}
+
+	exceptions, err := loadAllExceptionsFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading exceptions: %v\n", err)
+		return 2
+	}
+
+	// Proposals and history are informational; a missing directory is fine.
+	proposals, err := loadAllProposalsFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: loading proposals: %v\n", err)
+	}
+	history, err := loadHistoryItemsFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	report := buildRuleReport(*rule, constitution, exceptions, proposals, history, time.Now())
+
+	if *jsonOutput {
+		if err := output.PrintRuleReportJSON(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing JSON output: %v\n", err)
+			return 2
+		}
+	} else {
+		output.PrintRuleReportHuman(os.Stdout, report)
+	}
+	return 0
+}
+
+// ruleSummary converts a rule into its listing entry.
+func ruleSummary(r config.Rule) output.RuleSummary {
+	return output.RuleSummary{
+		ID:       r.ID,
+		Type:     r.Type,
+		Severity: r.Severity,
+		Enabled:  r.IsEnabled(),
+		Owner:    r.Owner,
+		Tags:     r.Tags,
+		Source:   r.Source,
+	}
+}
+
+// buildRuleReport collects a rule's metadata with the exceptions active at
+// now, its proposals that are not yet finalized, and its finalized history.
+func buildRuleReport(
+	rule config.Rule,
+	constitution *config.Constitution,
+	exceptions []*config.Exception,
+	proposals []*config.Proposal,
+	history []output.HistoryItem,
+	now time.Time,
+) *output.RuleReport {
+	report := &output.RuleReport{
+		RuleSummary: ruleSummary(rule),
+		Description: rule.Description,
+		Rationale:   rule.Rationale,
+		DocsURL:     rule.DocsURL,
+		Timeout:     rule.Timeout,
+		Config:      rule.Config,
+		Exceptions:  []output.ExceptionSummary{},
+		Proposals:   []output.ProposalSummary{},
+		History:     []output.HistoryItem{},
+	}
+	if rule.Owner != "" {
+		_, known := constitution.Roles[rule.Owner]
+		report.OwnerUnknown = !known
+	}
+
+	for _, e := range exceptions {
+		if e == nil || e.RuleID != rule.ID {
+			continue
+		}
+		if e.ExpiresAt != nil && e.ExpiresAt.Before(now) {
+			continue
+		}
+		summary := output.ExceptionSummary{
+			ID:        e.ID,
+			Paths:     e.Paths,
+			Reason:    e.Reason,
+			CreatedBy: e.CreatedBy,
+		}
+		if e.ExpiresAt != nil {
+			summary.ExpiresAt = e.ExpiresAt.Format("2006-01-02")
+		}
+		report.Exceptions = append(report.Exceptions, summary)
+	}
+
+	for _, p := range proposals {
+		if p.RuleID != rule.ID || (p.Status != "proposed" && p.Status != "accepted") {
+			continue
+		}
+		report.Proposals = append(report.Proposals, output.ProposalSummary{
+			ID:           p.ID,
+			RuleID:       p.RuleID,
+			ProposalType: p.ProposalType,
+			Status:       p.Status,
+			Description:  p.Change.Description,
+			CreatedBy:    p.CreatedBy,
+		})
+	}
+
+	for _, item := range history {
+		if item.RuleID == rule.ID {
+			report.History = append(report.History, item)
+		}
+	}
+
+	return report
+}
+
+func runRulesTest(args []string) int {
+	fs := flag.NewFlagSet("rules test", flag.ContinueOnError)
+	jsonOutput := fs.Bool("json", false, "Output results as JSON")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, rulesUsage) }
+
+	if err := fs.Parse(reorderArgs(args)); err != nil {
+		return 2
+	}
+
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	rulesFile, err := loadRulesFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading rules: %v\n", err)
+		return 2
+	}
+
+	rules := rulesFile.Rules
+	if fs.NArg() > 0 {
+		byID := make(map[string]config.Rule, len(rules))
+		for _, r := range rules {
+			byID[r.ID] = r
+		}
+		rules = nil
+		for _, id := range fs.Args() {
+			r, ok := byID[id]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: rule %q not found in rules.yml\n", id)
+				return 2
+			}
+			rules = append(rules, r)
+		}
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	report, err := runRuleExamples(ctx, rules, repoRoot(agreementsDir))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: rule tests interrupted")
+		return 2
+	}
+
+	if *jsonOutput {
+		if err := output.PrintRuleTestReportJSON(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing JSON output: %v\n", err)
+			return 2
+		}
+	} else {
+		output.PrintRuleTestReportHuman(os.Stdout, report)
+	}
+
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runRuleExamples runs the examples of the given rules and collects the
+// outcomes. It returns an error only if ctx is canceled.
+func runRuleExamples(ctx context.Context, rules []config.Rule, root string) (*output.RuleTestReport, error) {
+	report := &output.RuleTestReport{Results: []output.RuleTestResult{}}
+	for _, rule := range rules {
+		if len(rule.Examples) == 0 {
+			report.Untested = append(report.Untested, rule.ID)
+			continue
+		}
+
+		results, err := engine.RunExamples(ctx, rule, root)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range results {
+			result := output.RuleTestResult{
+				RuleID:  res.RuleID,
+				Example: res.Label(),
+				Expect:  res.Example.Expect,
+				Passed:  res.Passed(),
+				Failure: res.Failure(),
+			}
+			if !result.Passed {
+				result.Violations = buildViolationReports(res.Violations)
+			}
+			report.Results = append(report.Results, result)
+
+			if result.Passed {
+				report.Passed++
+			} else {
+				report.Failed++
+			}
+		}
+	}
+	return report, nil
+}
+
+func runRulesResolved() int {
+	agreementsDir, err := findAgreementsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	rulesFile, err := loadRulesFrom(agreementsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading rules: %v\n", err)
+		return 2
+	}
+
+	data, err := marshalResolvedRules(rulesFile.Rules)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Fprint(os.Stdout, string(data))
+	return 0
+}
+
+// marshalResolvedRules renders rules as a rules.yml document, annotating each
+// rule with the file it was defined in.
+func marshalResolvedRules(rules []config.Rule) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(&config.RulesFile{Rules: rules}); err != nil {
+		return nil, fmt.Errorf("encoding rules: %w", err)
+	}
+
+	// doc is a mapping whose "rules" value is the sequence of rules.
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "rules" {
+			continue
+		}
+		for j, item := range doc.Content[i+1].Content {
+			if rules[j].Source != "" {
+				item.HeadComment = "from " + rules[j].Source
+			}
+		}
+	}
+
+	return marshalToYAML(&doc)
+}
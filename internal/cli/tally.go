@@ -58,7 +58,7 @@ func runTally(args []string) int {
 	}
 
 	// Find proposal.
-	proposal, _, err := findProposalByID(agreementsDir, proposalID)
+	proposal, proposalPath, err := findProposalByID(agreementsDir, proposalID)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 2
@@ -74,6 +74,17 @@ func runTally(args []string) int {
 	// Compute tally.
 	tally := governance.ComputeTally(proposal, votes, constitution)
 
+	// Warn about proposal and vote commits that break the identity settings;
+	// finalize refuses such proposals.
+	identityViolations, err := proposalIdentityViolations(constitution, agreementsDir, proposalPath, proposalID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: checking identities: %v\n", err)
+	}
+	if len(identityViolations) > 0 {
+		fmt.Fprintln(os.Stderr, "Warning: commits of this proposal break the identity settings:")
+		printIdentityViolations(os.Stderr, identityViolations)
+	}
+
 	// Build report.
 	report := buildTallyReport(tally)
 
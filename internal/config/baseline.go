@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Baseline records known violations that are accepted for now, so that
+// adopting a strict rule on an existing codebase only fails new violations.
+type Baseline struct {
+	Entries []BaselineEntry `yaml:"entries"`
+}
+
+// BaselineEntry identifies a known violation. Fingerprint is derived from
+// the rule ID, file path and normalized snippet; RuleID and Path are kept for
+// readability. The snippet itself is not stored, so that the baseline file
+// does not trigger the very rules it records. Count is the number of
+// identical violations in the file, if more than one.
+type BaselineEntry struct {
+	Fingerprint string `yaml:"fingerprint"`
+	RuleID      string `yaml:"rule_id"`
+	Path        string `yaml:"path"`
+	Count       int    `yaml:"count,omitempty"`
+}
+
+// Occurrences returns the number of violations the entry covers: Count, or
+// one if Count is not set.
+func (e BaselineEntry) Occurrences() int {
+	if e.Count < 1 {
+		return 1
+	}
+	return e.Count
+}
+
+// LoadBaseline reads and parses a baseline YAML file from the given path.
+// A missing file yields an empty baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Baseline{}, nil
+		}
+		return nil, fmt.Errorf("reading baseline file %s: %w", path, err)
+	}
+
+	var b Baseline
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline file %s: %w", path, err)
+	}
+
+	return &b, nil
+}
+
+// SaveBaseline writes a Baseline to the given path as YAML.
+func SaveBaseline(path string, b *Baseline) error {
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("marshaling baseline: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating baseline directory %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing baseline file %s: %w", path, err)
+	}
+
+	return nil
+}
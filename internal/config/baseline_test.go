@@ -0,0 +1,63 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBaseline_FullFile(t *testing.T) {
+	content := `
+entries:
+  - fingerprint: 3f2a9c0d1e4b5a6c
+    rule_id: money_minor_units
+    path: domain/model/Price.kt
+  - fingerprint: 9b8e7d6c5a4f3e2d
+    rule_id: api_changelog
+    path: api/handler.go
+    count: 3
+`
+	path := writeTestFile(t, "baseline.yml", content)
+
+	b, err := LoadBaseline(path)
+	require.NoError(t, err)
+
+	require.Len(t, b.Entries, 2)
+	assert.Equal(t, "3f2a9c0d1e4b5a6c", b.Entries[0].Fingerprint)
+	assert.Equal(t, "money_minor_units", b.Entries[0].RuleID)
+	assert.Equal(t, "domain/model/Price.kt", b.Entries[0].Path)
+	assert.Equal(t, 1, b.Entries[0].Occurrences())
+	assert.Equal(t, "api_changelog", b.Entries[1].RuleID)
+	assert.Equal(t, 3, b.Entries[1].Occurrences())
+}
+
+func TestLoadBaseline_MissingFile(t *testing.T) {
+	b, err := LoadBaseline(filepath.Join(t.TempDir(), "baseline.yml"))
+	require.NoError(t, err)
+	assert.Empty(t, b.Entries)
+}
+
+func TestLoadBaseline_InvalidYAML(t *testing.T) {
+	path := writeTestFile(t, "baseline.yml", "entries: [not: valid: yaml")
+
+	_, err := LoadBaseline(path)
+	assert.Error(t, err)
+}
+
+func TestSaveBaseline_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "baseline.yml")
+	original := &Baseline{
+		Entries: []BaselineEntry{
+			{Fingerprint: "abc123", RuleID: "r1", Path: "a.go"},
+			{Fingerprint: "def456", RuleID: "r1", Path: "b.go", Count: 2},
+		},
+	}
+
+	require.NoError(t, SaveBaseline(path, original))
+
+	loaded, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, loaded)
+}
@@ -16,6 +16,7 @@ type Constitution struct {
 	Identity   Identity        `yaml:"identity"`
 	Roles      map[string]Role `yaml:"roles"`
 	LLM        LLMConfig       `yaml:"llm"`
+	Check      CheckConfig     `yaml:"check"`
 }
 
 // Governance defines the voting and proposal governance rules.
@@ -27,6 +28,7 @@ type Governance struct {
 	ProposalTTLDays    int                       `yaml:"proposal_ttl_days"`
 	PerRuleOverrides   map[string]RuleOverride   `yaml:"per_rule_overrides"`
 	Exceptions         ExceptionPolicy           `yaml:"exceptions"`
+	InlineSuppressions SuppressionPolicy         `yaml:"inline_suppressions"`
 }
 
 // VoterRef references a role that is eligible to vote.
@@ -50,10 +52,33 @@ type ExceptionPolicy struct {
 	RequireApproval bool `yaml:"require_approval"`
 }
 
-// Identity configures identity verification settings.
+// SuppressionPolicy configures inline guardian:ignore comments in source
+// code.
+type SuppressionPolicy struct {
+	// RequireReason makes a suppression without reason="..." ineffective.
+	RequireReason bool `yaml:"require_reason"`
+	// ForbiddenRules lists rules that cannot be suppressed inline; their
+	// violations need an exception or a proposal instead.
+	ForbiddenRules []string `yaml:"forbidden_rules"`
+}
+
+// Identity configures identity verification settings. guardian check
+// enforces them on the commits of the checked range, and tally and finalize
+// on the commits of a proposal's files.
 type Identity struct {
+	// AllowedDomains lists the email domains that commit authors and
+	// committers must use. Empty means any domain.
 	AllowedDomains       []string `yaml:"allowed_domains"`
 	RequireSignedCommits bool     `yaml:"require_signed_commits"`
+	// TrustedKeys lists the GPG key IDs or fingerprints and SSH key
+	// fingerprints (e.g., "SHA256:...") that signed commits must use. Empty
+	// means any key that git verifies as good.
+	TrustedKeys []string `yaml:"trusted_keys,omitempty"`
+}
+
+// HasChecks reports whether any identity check is configured.
+func (i Identity) HasChecks() bool {
+	return len(i.AllowedDomains) > 0 || i.RequireSignedCommits
 }
 
 // Role defines a named role and its members.
@@ -80,6 +105,15 @@ type LLMPrompts struct {
 	ProposeSystem string `yaml:"propose_system"`
 }
 
+// CheckConfig configures the behavior of guardian check.
+type CheckConfig struct {
+	// FailOnRuleError makes the check fail when a rule cannot be evaluated
+	// (e.g., an invalid regex or a missing config key). By default, such
+	// rules are reported under rule errors and the remaining rules still
+	// decide the result.
+	FailOnRuleError bool `yaml:"fail_on_rule_error"`
+}
+
 // LoadConstitution reads and parses a constitution.yml file from the given path.
 func LoadConstitution(path string) (*Constitution, error) {
 	data, err := os.ReadFile(path)
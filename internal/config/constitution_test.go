@@ -27,6 +27,9 @@ governance:
         type: unanimous
   exceptions:
     require_approval: false
+  inline_suppressions:
+    require_reason: true
+    forbidden_rules: [payment_rule]
 identity:
   allowed_domains: ["company.com", "corp.io"]
   require_signed_commits: false
@@ -45,6 +48,8 @@ llm:
   prompts:
     check_system: "custom check prompt"
     propose_system: "custom propose prompt"
+check:
+  fail_on_rule_error: true
 `
 	path := writeTestFile(t, "constitution.yml", content)
 
@@ -69,6 +74,10 @@ llm:
 	// Exceptions
 	assert.False(t, c.Governance.Exceptions.RequireApproval)
 
+	// Inline suppressions
+	assert.True(t, c.Governance.InlineSuppressions.RequireReason)
+	assert.Equal(t, []string{"payment_rule"}, c.Governance.InlineSuppressions.ForbiddenRules)
+
 	// Identity
 	assert.Equal(t, []string{"company.com", "corp.io"}, c.Identity.AllowedDomains)
 	assert.False(t, c.Identity.RequireSignedCommits)
@@ -84,6 +93,9 @@ llm:
 	assert.Equal(t, "deepseek-chat", c.LLM.Model)
 	assert.Equal(t, "custom check prompt", c.LLM.Prompts.CheckSystem)
 	assert.Equal(t, "custom propose prompt", c.LLM.Prompts.ProposeSystem)
+
+	// Check
+	assert.True(t, c.Check.FailOnRuleError)
 }
 
 func TestLoadConstitution_MinimalFile(t *testing.T) {
@@ -112,6 +124,7 @@ llm:
 	assert.Equal(t, 0, c.Governance.ProposalTTLDays)
 	assert.Empty(t, c.Identity.AllowedDomains)
 	assert.Equal(t, "openai", c.LLM.Provider)
+	assert.False(t, c.Check.FailOnRuleError)
 }
 
 func TestLoadConstitution_FileNotFound(t *testing.T) {
@@ -205,6 +218,7 @@ func TestLoadConstitution_RoundTrip(t *testing.T) {
 		Identity: Identity{
 			AllowedDomains:       []string{"example.com"},
 			RequireSignedCommits: true,
+			TrustedKeys:          []string{"3AA5C34371567BD2"},
 		},
 		Roles: map[string]Role{
 			"lead": {Members: []RoleMember{{Email: "lead@example.com"}}},
@@ -235,6 +249,7 @@ func TestLoadConstitution_RoundTrip(t *testing.T) {
 	assert.Equal(t, original.Governance.AllowVoteChange, loaded.Governance.AllowVoteChange)
 	assert.Equal(t, original.Identity.AllowedDomains, loaded.Identity.AllowedDomains)
 	assert.Equal(t, original.Identity.RequireSignedCommits, loaded.Identity.RequireSignedCommits)
+	assert.Equal(t, original.Identity.TrustedKeys, loaded.Identity.TrustedKeys)
 	assert.Equal(t, original.LLM.Endpoint, loaded.LLM.Endpoint)
 	assert.Equal(t, original.LLM.Model, loaded.LLM.Model)
 	assert.Equal(t, original.Governance.Exceptions.RequireApproval, loaded.Governance.Exceptions.RequireApproval)
@@ -259,3 +274,9 @@ func writeTestFile(t *testing.T, name, content string) string {
 	require.NoError(t, err)
 	return path
 }
+
+func TestIdentity_HasChecks(t *testing.T) {
+	assert.False(t, Identity{}.HasChecks())
+	assert.True(t, Identity{AllowedDomains: []string{"company.com"}}.HasChecks())
+	assert.True(t, Identity{RequireSignedCommits: true}.HasChecks())
+}
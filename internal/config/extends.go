@@ -0,0 +1,278 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+	"gopkg.in/yaml.v3"
+)
+
+// RulesSource points to a rules file whose rules are inherited through
+// extends. A source is either a local file (Path alone), or a file in a
+// local git clone read at a pinned revision (Repo, Ref and Path).
+type RulesSource struct {
+	// Path is the rules file. For a local source it is relative to the
+	// directory of the file that extends it; with Repo it is relative to the
+	// repository root.
+	Path string `yaml:"path"`
+	// Repo is a local git clone (e.g., of an org-wide rules repository),
+	// relative to the directory of the file that extends it.
+	Repo string `yaml:"repo,omitempty"`
+	// Ref is the revision of Repo to read Path at, such as a tag. It
+	// defaults to HEAD; pin it so rules change only when rules.yml does.
+	Ref string `yaml:"ref,omitempty"`
+}
+
+// RuleOverrides adjusts the rules inherited through extends. Every rule ID
+// must name an inherited rule.
+type RuleOverrides struct {
+	// Disable lists inherited rules to drop.
+	Disable []string `yaml:"disable,omitempty"`
+	// Severity maps inherited rule IDs to a new severity.
+	Severity map[string]string `yaml:"severity,omitempty"`
+}
+
+// ResolveRules loads the rules file at path and all files it extends, and
+// returns the effective rule set. The rules of each extended file are
+// inherited in order, then the file's overrides are applied to them, then the
+// file's own rules are appended. Extended files may extend others; a cycle is
+// an error. Defining the same rule ID in two different files is an error;
+// to replace an inherited rule, disable it and define a new one. Each
+// returned rule records its Source, and Files lists the local files read.
+func ResolveRules(path string) (*RulesFile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving rules file %s: %w", path, err)
+	}
+
+	r := &rulesResolver{rootDir: filepath.Dir(abs)}
+	rules, err := r.resolve(rulesLocation{path: abs}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RulesFile{Rules: rules, Files: r.files}, nil
+}
+
+// rulesResolver holds the state of a single ResolveRules call.
+type rulesResolver struct {
+	rootDir string   // directory of the root rules file
+	files   []string // local files read so far
+}
+
+// rulesLocation identifies a rules file while resolving extends.
+type rulesLocation struct {
+	repo string // absolute path of a git clone; empty for a local file
+	ref  string // revision to read path at; set with repo
+	path string // absolute file path, or slash-separated path within repo
+}
+
+// key uniquely identifies the location for cycle detection.
+func (l rulesLocation) key() string {
+	if l.repo == "" {
+		return l.path
+	}
+	return l.repo + "@" + l.ref + ":" + l.path
+}
+
+// dir returns the directory on disk that relative sources are resolved in.
+func (l rulesLocation) dir() string {
+	if l.repo == "" {
+		return filepath.Dir(l.path)
+	}
+	return filepath.Join(l.repo, filepath.FromSlash(path.Dir(l.path)))
+}
+
+// read returns the content of the rules file.
+func (l rulesLocation) read() ([]byte, error) {
+	if l.repo == "" {
+		return os.ReadFile(l.path)
+	}
+	return git.ShowFileIn(l.repo, l.ref, l.path)
+}
+
+// locate returns the location of a source extended by the file at l.
+func (l rulesLocation) locate(src RulesSource) (rulesLocation, error) {
+	if src.Path == "" {
+		return rulesLocation{}, fmt.Errorf("extends entry must set path")
+	}
+	if src.Ref != "" && src.Repo == "" {
+		return rulesLocation{}, fmt.Errorf("extends entry %q: ref requires repo", src.Path)
+	}
+
+	// A plain path inside a file read from git refers to the same repository
+	// and revision.
+	if src.Repo == "" && l.repo != "" {
+		p := filepath.ToSlash(src.Path)
+		if !path.IsAbs(p) {
+			p = path.Join(path.Dir(l.path), p)
+		}
+		return rulesLocation{repo: l.repo, ref: l.ref, path: strings.TrimPrefix(path.Clean(p), "/")}, nil
+	}
+
+	if src.Repo == "" {
+		return rulesLocation{path: absIn(l.dir(), src.Path)}, nil
+	}
+
+	ref := src.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return rulesLocation{
+		repo: absIn(l.dir(), src.Repo),
+		ref:  ref,
+		path: strings.TrimPrefix(path.Clean(filepath.ToSlash(src.Path)), "/"),
+	}, nil
+}
+
+// absIn resolves p relative to dir unless it is already absolute.
+func absIn(dir, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(dir, p)
+}
+
+// display returns a short name for a location, relative to the root rules
+// file's directory where possible.
+func (r *rulesResolver) display(l rulesLocation) string {
+	rel := func(p string) string {
+		if rp, err := filepath.Rel(r.rootDir, p); err == nil {
+			return filepath.ToSlash(rp)
+		}
+		return p
+	}
+	if l.repo == "" {
+		return rel(l.path)
+	}
+	return rel(l.repo) + "@" + l.ref + ":" + l.path
+}
+
+// resolve returns the effective rules of the file at loc. stack holds the
+// files currently being resolved, outermost first.
+func (r *rulesResolver) resolve(loc rulesLocation, stack []rulesLocation) ([]Rule, error) {
+	name := r.display(loc)
+	for i, s := range stack {
+		if s.key() == loc.key() {
+			chain := make([]string, 0, len(stack)-i+1)
+			for _, c := range stack[i:] {
+				chain = append(chain, r.display(c))
+			}
+			chain = append(chain, name)
+			return nil, fmt.Errorf("extends cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	stack = append(stack, loc)
+
+	data, err := loc.read()
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file %s: %w", name, err)
+	}
+	if loc.repo == "" {
+		r.files = append(r.files, loc.path)
+	}
+
+	var f RulesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing rules file %s: %w", name, err)
+	}
+
+	var inherited []Rule
+	for _, src := range f.Extends {
+		child, err := loc.locate(src)
+		if err != nil {
+			return nil, fmt.Errorf("rules file %s: %w", name, err)
+		}
+		rules, err := r.resolve(child, stack)
+		if err != nil {
+			return nil, err
+		}
+		inherited = append(inherited, rules...)
+	}
+
+	inherited, err = mergeInherited(inherited)
+	if err != nil {
+		return nil, fmt.Errorf("rules file %s: %w", name, err)
+	}
+
+	inherited, err = applyOverrides(inherited, f.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("rules file %s: overrides: %w", name, err)
+	}
+
+	sources := make(map[string]string, len(inherited))
+	for _, rule := range inherited {
+		sources[rule.ID] = rule.Source
+	}
+	for i := range f.Rules {
+		if src, ok := sources[f.Rules[i].ID]; ok {
+			return nil, fmt.Errorf("rule %q is defined in both %s and %s; disable the inherited rule in overrides to replace it", f.Rules[i].ID, src, name)
+		}
+		f.Rules[i].Source = name
+	}
+	return append(inherited, f.Rules...), nil
+}
+
+// applyOverrides disables and re-grades inherited rules.
+func applyOverrides(rules []Rule, o RuleOverrides) ([]Rule, error) {
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.ID] = true
+	}
+
+	disabled := make(map[string]bool, len(o.Disable))
+	for _, id := range o.Disable {
+		if !known[id] {
+			return nil, fmt.Errorf("disable: no inherited rule %q", id)
+		}
+		disabled[id] = true
+	}
+	for id, severity := range o.Severity {
+		if !known[id] {
+			return nil, fmt.Errorf("severity: no inherited rule %q", id)
+		}
+		if !validSeverities[severity] {
+			return nil, fmt.Errorf("severity: %q for rule %q is invalid; must be one of: error, warning", severity, id)
+		}
+	}
+
+	result := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if disabled[rule.ID] {
+			continue
+		}
+		if severity, ok := o.Severity[rule.ID]; ok {
+			rule.Severity = severity
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// mergeInherited rejects rule IDs inherited from more than one file. A rule
+// reached twice through different extends paths with identical settings
+// (e.g., two packs extending the same base) is kept once.
+func mergeInherited(rules []Rule) ([]Rule, error) {
+	seen := make(map[string]Rule, len(rules))
+	result := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		prev, ok := seen[rule.ID]
+		switch {
+		case !ok:
+			seen[rule.ID] = rule
+			result = append(result, rule)
+		case reflect.DeepEqual(prev, rule):
+			// Same rule reached through two extends paths.
+		case prev.Source == rule.Source:
+			return nil, fmt.Errorf("rule %q from %s is inherited twice with different overrides", rule.ID, rule.Source)
+		default:
+			return nil, fmt.Errorf("rule %q is defined in both %s and %s; disable one of them in overrides", rule.ID, prev.Source, rule.Source)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,297 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles writes the given files (relative path -> content) under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// ruleIDs returns the IDs of rules in order.
+func ruleIDs(rules []Rule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, r := range rules {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+const baseRules = `
+rules:
+  - id: no_todo
+    description: No TODOs
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["TODO"]
+    severity: error
+  - id: money_minor_units
+    description: Money must use minor units
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["\\bDouble\\b"]
+    severity: error
+`
+
+func TestResolveRules_NoExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"rules.yml": baseRules})
+
+	r, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"no_todo", "money_minor_units"}, ruleIDs(r.Rules))
+	assert.Equal(t, "rules.yml", r.Rules[0].Source)
+	assert.Equal(t, []string{filepath.Join(dir, "rules.yml")}, r.Files)
+}
+
+func TestResolveRules_LocalExtendsWithOverrides(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"org/base.yml": baseRules,
+		"repo/.agreements/rules.yml": `
+extends:
+  - path: ../../org/base.yml
+overrides:
+  disable: [no_todo]
+  severity:
+    money_minor_units: warning
+rules:
+  - id: local_rule
+    description: Local rule
+    type: changes_forbidden
+    config:
+      paths: ["migrations/**"]
+    severity: error
+`,
+	})
+
+	r, err := ResolveRules(filepath.Join(dir, "repo/.agreements/rules.yml"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"money_minor_units", "local_rule"}, ruleIDs(r.Rules))
+	assert.Equal(t, "warning", r.Rules[0].Severity)
+	assert.Equal(t, "../../org/base.yml", r.Rules[0].Source)
+	assert.Equal(t, "rules.yml", r.Rules[1].Source)
+	assert.Len(t, r.Files, 2)
+}
+
+func TestResolveRules_NestedExtendsAndDiamond(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base.yml": baseRules,
+		"backend.yml": `
+extends:
+  - path: base.yml
+rules:
+  - id: backend_rule
+    description: Backend
+    type: changes_forbidden
+    config:
+      paths: ["db/**"]
+    severity: error
+`,
+		"security.yml": `
+extends:
+  - path: base.yml
+rules: []
+`,
+		"rules.yml": `
+extends:
+  - path: backend.yml
+  - path: security.yml
+`,
+	})
+
+	r, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no_todo", "money_minor_units", "backend_rule"}, ruleIDs(r.Rules))
+}
+
+func TestResolveRules_DiamondWithDifferentOverrides(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base.yml": baseRules,
+		"a.yml": `
+extends:
+  - path: base.yml
+overrides:
+  severity:
+    no_todo: warning
+`,
+		"b.yml": `
+extends:
+  - path: base.yml
+`,
+		"rules.yml": `
+extends:
+  - path: a.yml
+  - path: b.yml
+`,
+	})
+
+	_, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rule "no_todo" from base.yml is inherited twice with different overrides`)
+}
+
+func TestResolveRules_Cycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"rules.yml": "extends:\n  - path: a.yml\n",
+		"a.yml":     "extends:\n  - path: b.yml\n",
+		"b.yml":     "extends:\n  - path: a.yml\n",
+	})
+
+	_, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "extends cycle: a.yml -> b.yml -> a.yml")
+}
+
+func TestResolveRules_DuplicateLocalRule(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base.yml": baseRules,
+		"rules.yml": `
+extends:
+  - path: base.yml
+rules:
+  - id: no_todo
+    description: Stricter TODO rule
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["TODO|FIXME"]
+    severity: error
+`,
+	})
+
+	_, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rule "no_todo" is defined in both base.yml and rules.yml`)
+
+	// Disabling the inherited rule allows replacing it.
+	writeFiles(t, dir, map[string]string{
+		"rules.yml": `
+extends:
+  - path: base.yml
+overrides:
+  disable: [no_todo]
+rules:
+  - id: no_todo
+    description: Stricter TODO rule
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["TODO|FIXME"]
+    severity: error
+`,
+	})
+
+	r, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"money_minor_units", "no_todo"}, ruleIDs(r.Rules))
+	assert.Equal(t, "Stricter TODO rule", r.Rules[1].Description)
+}
+
+func TestResolveRules_InvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		want      string
+	}{
+		{"unknown disable", "  disable: [nope]\n", `disable: no inherited rule "nope"`},
+		{"unknown severity", "  severity:\n    nope: warning\n", `severity: no inherited rule "nope"`},
+		{"bad severity", "  severity:\n    no_todo: critical\n", `"critical" for rule "no_todo" is invalid`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{
+				"base.yml":  baseRules,
+				"rules.yml": "extends:\n  - path: base.yml\noverrides:\n" + tt.overrides,
+			})
+
+			_, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rules file rules.yml: overrides: ")
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestResolveRules_InvalidSource(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"rules.yml": "extends:\n  - path: base.yml\n    ref: v1\n",
+	})
+
+	_, err := ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ref requires repo")
+
+	writeFiles(t, dir, map[string]string{
+		"rules.yml": "extends:\n  - path: missing.yml\n",
+	})
+	_, err = ResolveRules(filepath.Join(dir, "rules.yml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading rules file missing.yml")
+}
+
+func TestResolveRules_GitSourcePinnedRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	orgRepo := filepath.Join(dir, "org-rules")
+	writeFiles(t, orgRepo, map[string]string{
+		"packs/base.yml": baseRules,
+		"packs/backend.yml": `
+extends:
+  - path: base.yml
+rules: []
+`,
+	})
+	runGitIn(t, orgRepo, "init", "-q")
+	runGitIn(t, orgRepo, "add", ".")
+	runGitIn(t, orgRepo, "commit", "-q", "-m", "v1")
+	runGitIn(t, orgRepo, "tag", "v1")
+
+	// A later commit removes a rule; the pinned tag still sees it.
+	writeFiles(t, orgRepo, map[string]string{"packs/base.yml": "rules: []\n"})
+	runGitIn(t, orgRepo, "commit", "-q", "-am", "v2")
+
+	writeFiles(t, dir, map[string]string{
+		"repo/rules.yml": `
+extends:
+  - repo: ../org-rules
+    ref: v1
+    path: packs/backend.yml
+`,
+	})
+
+	r, err := ResolveRules(filepath.Join(dir, "repo/rules.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no_todo", "money_minor_units"}, ruleIDs(r.Rules))
+	assert.Equal(t, "../org-rules@v1:packs/base.yml", r.Rules[0].Source)
+	assert.Len(t, r.Files, 1, "files read from git are not local files")
+}
+
+func runGitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -3,13 +3,24 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // RulesFile represents the rules.yml configuration containing all rule definitions.
 type RulesFile struct {
-	Rules []Rule `yaml:"rules"`
+	// Extends lists other rules files whose rules are inherited. See
+	// ResolveRules for the merge semantics.
+	Extends []RulesSource `yaml:"extends,omitempty"`
+	// Overrides adjusts the inherited rules.
+	Overrides RuleOverrides `yaml:"overrides,omitempty"`
+	Rules     []Rule        `yaml:"rules"`
+
+	// Files lists the local files read by ResolveRules (absolute paths,
+	// starting with the root rules file). It is empty after LoadRules.
+	Files []string `yaml:"-"`
 }
 
 // Rule defines a single rule that Guardian checks code changes against.
@@ -19,6 +30,133 @@ type Rule struct {
 	Type        string                 `yaml:"type"`
 	Config      map[string]interface{} `yaml:"config"`
 	Severity    string                 `yaml:"severity"`
+	// Timeout bounds how long the rule may run, as a Go duration such as
+	// "30s". A rule without a timeout runs until the check is canceled.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Owner is the constitution role responsible for the rule.
+	Owner string `yaml:"owner,omitempty"`
+	// DocsURL links to further documentation, such as an ADR.
+	DocsURL string `yaml:"docs_url,omitempty"`
+	// Tags group rules, e.g., for guardian check --tags.
+	Tags []string `yaml:"tags,omitempty"`
+	// Enabled turns the rule off when set to false. Rules are enabled by
+	// default.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Rationale explains why the rule exists. It is shown by guardian rules
+	// show and given to the LLM.
+	Rationale string `yaml:"rationale,omitempty"`
+	// Examples are fixtures that guardian rules test runs through the rule's
+	// checker.
+	Examples []RuleExample `yaml:"examples,omitempty"`
+	// Fix rewrites the violating added lines for guardian check --fix.
+	Fix *RuleFix `yaml:"fix,omitempty"`
+
+	// Source names the rules file the rule was defined in. It is set by
+	// ResolveRules.
+	Source string `yaml:"-"`
+}
+
+// RuleFix is an automatic fix for a rule's violations: every match of Regex
+// on a violating added line is replaced by Replace, which may refer to
+// submatches as $1 or ${name} (see regexp.Regexp.Expand).
+type RuleFix struct {
+	Regex   string `yaml:"regex"`
+	Replace string `yaml:"replace"`
+}
+
+// Validate checks that the fix has a valid regex.
+func (f RuleFix) Validate() error {
+	if f.Regex == "" {
+		return fmt.Errorf("regex is required")
+	}
+	if _, err := regexp.Compile(f.Regex); err != nil {
+		return fmt.Errorf("regex %q is invalid: %w", f.Regex, err)
+	}
+	return nil
+}
+
+// Expected outcomes of a rule example.
+const (
+	ExpectViolation = "violation"
+	ExpectPass      = "pass"
+)
+
+// RuleExample is a change together with whether the rule should report it.
+// The change is either Lines added to a new file at Path, a unified Diff,
+// or Commits.
+type RuleExample struct {
+	Name string `yaml:"name,omitempty"`
+	// Path is the file that Lines are added to; it is matched by globs such
+	// as only_in_paths like any changed file.
+	Path  string   `yaml:"path,omitempty"`
+	Lines []string `yaml:"lines,omitempty"`
+	// Diff is a unified diff as produced by git diff, for changes that are
+	// not plain additions (e.g., removed lines or several files).
+	Diff string `yaml:"diff,omitempty"`
+	// Commits are the messages of the commits in a range with no diff, for
+	// rules that check commits (commit_message).
+	Commits []string `yaml:"commits,omitempty"`
+	// Expect is "violation" if the rule must report the change and "pass"
+	// if it must not.
+	Expect string `yaml:"expect"`
+}
+
+// Validate checks that the example describes exactly one change and a known
+// outcome.
+func (e RuleExample) Validate() error {
+	given := 0
+	for _, set := range []bool{len(e.Lines) > 0, e.Diff != "", len(e.Commits) > 0} {
+		if set {
+			given++
+		}
+	}
+	switch {
+	case given > 1:
+		return fmt.Errorf("lines, diff and commits are mutually exclusive")
+	case given == 0:
+		return fmt.Errorf("lines, diff or commits is required")
+	case len(e.Lines) > 0 && e.Path == "":
+		return fmt.Errorf("path is required with lines")
+	}
+	if e.Expect != ExpectViolation && e.Expect != ExpectPass {
+		return fmt.Errorf("expect %q is invalid; must be one of: violation, pass", e.Expect)
+	}
+	return nil
+}
+
+// TimeoutDuration parses the rule's timeout. It returns zero if no timeout
+// is set.
+func (r Rule) TimeoutDuration() (time.Duration, error) {
+	if r.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("timeout %q is invalid: %w", r.Timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout %q must be positive", r.Timeout)
+	}
+	return d, nil
+}
+
+// IsEnabled reports whether the rule is checked. Rules without an enabled
+// field are enabled.
+func (r Rule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// HasAnyTag reports whether the rule has at least one of the given tags.
+func (r Rule) HasAnyTag(tags []string) bool {
+	for _, want := range tags {
+		for _, tag := range r.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // LoadRules reads and parses a rules.yml file from the given path.
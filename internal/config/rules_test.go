@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -114,6 +115,186 @@ rules:
 	assert.Equal(t, "single_rule", r.Rules[0].ID)
 }
 
+func TestLoadRules_Timeout(t *testing.T) {
+	content := `
+rules:
+  - id: slow_rule
+    description: A rule with a timeout
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["TODO"]
+    severity: warning
+    timeout: 1m30s
+`
+	path := writeTestFile(t, "rules.yml", content)
+
+	r, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, r.Rules, 1)
+
+	d, err := r.Rules[0].TimeoutDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+}
+
+func TestLoadRules_Metadata(t *testing.T) {
+	content := `
+rules:
+  - id: no_secrets
+    description: No hardcoded secrets
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["password\\s*="]
+    severity: error
+    owner: security
+    docs_url: https://wiki.example.com/adr/12
+    tags: [security, compliance]
+    rationale: Leaked credentials must be rotated.
+  - id: old_rule
+    description: Retired rule
+    type: diff_pattern_forbidden
+    severity: warning
+    enabled: false
+`
+	path := writeTestFile(t, "rules.yml", content)
+
+	r, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, r.Rules, 2)
+
+	rule := r.Rules[0]
+	assert.Equal(t, "security", rule.Owner)
+	assert.Equal(t, "https://wiki.example.com/adr/12", rule.DocsURL)
+	assert.Equal(t, []string{"security", "compliance"}, rule.Tags)
+	assert.Equal(t, "Leaked credentials must be rotated.", rule.Rationale)
+	assert.True(t, rule.IsEnabled())
+	assert.False(t, r.Rules[1].IsEnabled())
+}
+
+func TestLoadRules_Examples(t *testing.T) {
+	content := `
+rules:
+  - id: money
+    description: No Double
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["\\bDouble\\b"]
+    severity: error
+    examples:
+      - name: double field
+        path: domain/Price.kt
+        lines: ["val amount: Double"]
+        expect: violation
+      - diff: |
+          diff --git a/a.kt b/a.kt
+          --- a/a.kt
+          +++ b/a.kt
+          @@ -1 +1 @@
+          -val amount: Double
+          +val amount: Long
+        expect: pass
+      - commits: ["fix: totals", "WIP"]
+        expect: pass
+`
+	path := writeTestFile(t, "rules.yml", content)
+
+	r, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, r.Rules[0].Examples, 3)
+
+	first := r.Rules[0].Examples[0]
+	assert.Equal(t, "double field", first.Name)
+	assert.Equal(t, []string{"val amount: Double"}, first.Lines)
+	assert.Equal(t, ExpectViolation, first.Expect)
+	assert.Contains(t, r.Rules[0].Examples[1].Diff, "+val amount: Long\n")
+	assert.Equal(t, ExpectPass, r.Rules[0].Examples[1].Expect)
+	assert.Equal(t, []string{"fix: totals", "WIP"}, r.Rules[0].Examples[2].Commits)
+}
+
+func TestRuleExample_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		example RuleExample
+		want    string
+	}{
+		{"lines", RuleExample{Path: "a.kt", Lines: []string{"x"}, Expect: "pass"}, ""},
+		{"diff", RuleExample{Diff: "diff --git a/a b/a", Expect: "violation"}, ""},
+		{"commits", RuleExample{Commits: []string{"fix: x"}, Expect: "pass"}, ""},
+		{"both", RuleExample{Path: "a.kt", Lines: []string{"x"}, Diff: "d", Expect: "pass"}, "mutually exclusive"},
+		{"diff and commits", RuleExample{Diff: "d", Commits: []string{"fix: x"}, Expect: "pass"}, "mutually exclusive"},
+		{"neither", RuleExample{Expect: "pass"}, "lines, diff or commits is required"},
+		{"no path", RuleExample{Lines: []string{"x"}, Expect: "pass"}, "path is required"},
+		{"bad expect", RuleExample{Diff: "d", Expect: "match"}, `expect "match" is invalid`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.example.Validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadRules_Fix(t *testing.T) {
+	content := `
+rules:
+  - id: money
+    description: No Double
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["\\bDouble\\b"]
+    severity: error
+    fix:
+      regex: "\\bDouble\\b"
+      replace: Long
+  - id: other
+    description: No fix
+    type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["x"]
+    severity: warning
+`
+	path := writeTestFile(t, "rules.yml", content)
+
+	r, err := LoadRules(path)
+	require.NoError(t, err)
+	require.NotNil(t, r.Rules[0].Fix)
+	assert.Equal(t, `\bDouble\b`, r.Rules[0].Fix.Regex)
+	assert.Equal(t, "Long", r.Rules[0].Fix.Replace)
+	assert.Nil(t, r.Rules[1].Fix)
+}
+
+func TestRuleFix_Validate(t *testing.T) {
+	assert.NoError(t, RuleFix{Regex: `(\w+)!`, Replace: "$1"}.Validate())
+	assert.NoError(t, RuleFix{Regex: `\s+$`}.Validate(), "an empty replacement deletes the match")
+
+	err := RuleFix{Replace: "x"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "regex is required")
+
+	err = RuleFix{Regex: "[", Replace: "x"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `regex "[" is invalid`)
+}
+
+func TestRule_HasAnyTag(t *testing.T) {
+	r := Rule{Tags: []string{"security", "api"}}
+	assert.True(t, r.HasAnyTag([]string{"ui", "api"}))
+	assert.False(t, r.HasAnyTag([]string{"ui"}))
+	assert.False(t, Rule{}.HasAnyTag([]string{"api"}))
+}
+
+func TestRule_TimeoutDurationUnset(t *testing.T) {
+	d, err := Rule{}.TimeoutDuration()
+	require.NoError(t, err)
+	assert.Zero(t, d)
+}
+
 func TestLoadRules_RuleWithoutConfig(t *testing.T) {
 	content := `
 rules:
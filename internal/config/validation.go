@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -99,6 +100,27 @@ func ValidateConstitution(c *Constitution) error {
 		}
 	}
 
+	for i, ruleID := range c.Governance.InlineSuppressions.ForbiddenRules {
+		if ruleID == "" {
+			errs = append(errs, fmt.Sprintf("governance.inline_suppressions.forbidden_rules[%d] must not be empty", i))
+		}
+	}
+
+	// Validate identity
+	for i, domain := range c.Identity.AllowedDomains {
+		if domain == "" || strings.Contains(domain, "@") {
+			errs = append(errs, fmt.Sprintf("identity.allowed_domains[%d] %q must be a domain such as company.com", i, domain))
+		}
+	}
+	for i, key := range c.Identity.TrustedKeys {
+		if key == "" {
+			errs = append(errs, fmt.Sprintf("identity.trusted_keys[%d] must not be empty", i))
+		}
+	}
+	if len(c.Identity.TrustedKeys) > 0 && !c.Identity.RequireSignedCommits {
+		errs = append(errs, "identity.trusted_keys requires identity.require_signed_commits to be true")
+	}
+
 	// Validate roles
 	if len(c.Roles) == 0 {
 		errs = append(errs, "roles must not be empty")
@@ -170,6 +192,34 @@ func ValidateRules(r *RulesFile) error {
 		} else if !validSeverities[rule.Severity] {
 			errs = append(errs, fmt.Sprintf("rules[%d].severity %q is invalid; must be one of: error, warning", i, rule.Severity))
 		}
+
+		if _, err := rule.TimeoutDuration(); err != nil {
+			errs = append(errs, fmt.Sprintf("rules[%d].%v", i, err))
+		}
+
+		if rule.DocsURL != "" {
+			if u, err := url.Parse(rule.DocsURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Sprintf("rules[%d].docs_url %q must be an http or https URL", i, rule.DocsURL))
+			}
+		}
+
+		for j, tag := range rule.Tags {
+			if strings.TrimSpace(tag) == "" {
+				errs = append(errs, fmt.Sprintf("rules[%d].tags[%d] must not be empty", i, j))
+			}
+		}
+
+		for j, example := range rule.Examples {
+			if err := example.Validate(); err != nil {
+				errs = append(errs, fmt.Sprintf("rules[%d].examples[%d]: %v", i, j, err))
+			}
+		}
+
+		if rule.Fix != nil {
+			if err := rule.Fix.Validate(); err != nil {
+				errs = append(errs, fmt.Sprintf("rules[%d].fix: %v", i, err))
+			}
+		}
 	}
 
 	if len(errs) > 0 {
@@ -58,6 +58,14 @@ func TestValidateConstitution_EmptyVoters(t *testing.T) {
 	assert.Contains(t, err.Error(), "governance.voters must not be empty")
 }
 
+func TestValidateConstitution_EmptyForbiddenSuppressionRule(t *testing.T) {
+	c := validConstitution()
+	c.Governance.InlineSuppressions.ForbiddenRules = []string{"payment_rule", ""}
+	err := ValidateConstitution(c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "governance.inline_suppressions.forbidden_rules[1] must not be empty")
+}
+
 func TestValidateConstitution_EmptyVoterRole(t *testing.T) {
 	c := validConstitution()
 	c.Governance.Voters = append(c.Governance.Voters, VoterRef{Role: ""})
@@ -268,6 +276,27 @@ func TestValidateConstitution_MultipleErrors(t *testing.T) {
 	assert.Contains(t, errStr, "llm.provider")
 }
 
+func TestValidateConstitution_Identity(t *testing.T) {
+	c := validConstitution()
+	c.Identity = Identity{
+		AllowedDomains:       []string{"company.com"},
+		RequireSignedCommits: true,
+		TrustedKeys:          []string{"3AA5C34371567BD2", "SHA256:VbPzrbMgm1pWnWJd2ieB3anJ3yxcphZRRLLJKkXq5ys"},
+	}
+	assert.NoError(t, ValidateConstitution(c))
+
+	c.Identity = Identity{
+		AllowedDomains: []string{"company.com", "", "dev@corp.io"},
+		TrustedKeys:    []string{""},
+	}
+	err := ValidateConstitution(c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `identity.allowed_domains[1] "" must be a domain such as company.com`)
+	assert.Contains(t, err.Error(), `identity.allowed_domains[2] "dev@corp.io" must be a domain`)
+	assert.Contains(t, err.Error(), "identity.trusted_keys[0] must not be empty")
+	assert.Contains(t, err.Error(), "identity.trusted_keys requires identity.require_signed_commits to be true")
+}
+
 // --- Rules validation tests ---
 
 func validRulesFile() *RulesFile {
@@ -353,6 +382,51 @@ func TestValidateRules_InvalidSeverity(t *testing.T) {
 	assert.Contains(t, err.Error(), "rules[0].severity \"critical\" is invalid")
 }
 
+func TestValidateRules_InvalidTimeout(t *testing.T) {
+	for _, timeout := range []string{"soon", "0s", "-5s"} {
+		t.Run(timeout, func(t *testing.T) {
+			r := validRulesFile()
+			r.Rules[0].Timeout = timeout
+			err := ValidateRules(r)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rules[0].timeout")
+		})
+	}
+}
+
+func TestValidateRules_Metadata(t *testing.T) {
+	r := validRulesFile()
+	r.Rules[0].DocsURL = "https://wiki.example.com/adr/12"
+	r.Rules[0].Tags = []string{"security"}
+	assert.NoError(t, ValidateRules(r))
+
+	r.Rules[0].DocsURL = "wiki/adr/12"
+	r.Rules[0].Tags = []string{"security", " "}
+	err := ValidateRules(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rules[0].docs_url "wiki/adr/12" must be an http or https URL`)
+	assert.Contains(t, err.Error(), "rules[0].tags[1] must not be empty")
+}
+
+func TestValidateRules_InvalidExample(t *testing.T) {
+	r := validRulesFile()
+	r.Rules[0].Examples = []RuleExample{
+		{Path: "a.kt", Lines: []string{"x"}, Expect: "pass"},
+		{Lines: []string{"x"}, Expect: "pass"},
+	}
+	err := ValidateRules(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules[0].examples[1]: path is required with lines")
+}
+
+func TestValidateRules_InvalidFix(t *testing.T) {
+	r := validRulesFile()
+	r.Rules[0].Fix = &RuleFix{Regex: "(", Replace: "x"}
+	err := ValidateRules(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `rules[0].fix: regex "(" is invalid`)
+}
+
 func TestValidateRules_ValidSeverities(t *testing.T) {
 	for _, sev := range []string{"error", "warning"} {
 		t.Run(sev, func(t *testing.T) {
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+)
+
+// Fingerprint returns a stable identifier for a violation, built from its
+// rule ID, file path and normalized snippet. Line numbers are deliberately
+// left out so that a violation keeps its fingerprint when unrelated lines
+// above it are added or removed.
+func Fingerprint(v Violation) string {
+	h := sha256.New()
+	h.Write([]byte(v.RuleID))
+	h.Write([]byte{0})
+	h.Write([]byte(v.FilePath))
+	h.Write([]byte{0})
+	h.Write([]byte(NormalizeSnippet(v.DiffSnippet)))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// NormalizeSnippet strips the diff marker from each line of a snippet and
+// collapses whitespace, so that re-indenting a line does not change its
+// fingerprint.
+func NormalizeSnippet(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			line = line[1:]
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			normalized = append(normalized, strings.Join(fields, " "))
+		}
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// NewBaselineEntry creates the baseline entry that suppresses v, and no other
+// violation with the same fingerprint.
+func NewBaselineEntry(v Violation) config.BaselineEntry {
+	return config.BaselineEntry{
+		Fingerprint: Fingerprint(v),
+		RuleID:      v.RuleID,
+		Path:        v.FilePath,
+	}
+}
+
+// applyBaseline splits violations into those not covered by the baseline and
+// those whose fingerprint is recorded in it. An entry covers as many
+// violations as it records occurrences; identical violations beyond that
+// count are new.
+func (e *Engine) applyBaseline(violations []Violation) ([]Violation, []Violation) {
+	if len(e.Baseline) == 0 {
+		return violations, nil
+	}
+
+	remaining := make(map[string]int, len(e.Baseline))
+	for _, entry := range e.Baseline {
+		remaining[entry.Fingerprint] += entry.Occurrences()
+	}
+
+	fresh := make([]Violation, 0, len(violations))
+	var baselined []Violation
+	for _, v := range violations {
+		fp := Fingerprint(v)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			baselined = append(baselined, v)
+			continue
+		}
+		fresh = append(fresh, v)
+	}
+
+	return fresh, baselined
+}
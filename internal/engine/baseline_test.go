@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint_IgnoresLineAndWhitespace(t *testing.T) {
+	a := Violation{RuleID: "money", FilePath: "Price.kt", Line: 3, DiffSnippet: "+    val amount: Double"}
+	b := Violation{RuleID: "money", FilePath: "Price.kt", Line: 40, DiffSnippet: "+val  amount:\tDouble"}
+
+	assert.Equal(t, Fingerprint(a), Fingerprint(b))
+	assert.Len(t, Fingerprint(a), 16)
+}
+
+func TestFingerprint_DiffersByRulePathAndSnippet(t *testing.T) {
+	base := Violation{RuleID: "money", FilePath: "Price.kt", DiffSnippet: "+val amount: Double"}
+
+	otherRule := base
+	otherRule.RuleID = "other"
+	otherPath := base
+	otherPath.FilePath = "Cost.kt"
+	otherSnippet := base
+	otherSnippet.DiffSnippet = "+val total: Double"
+
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherRule))
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherPath))
+	assert.NotEqual(t, Fingerprint(base), Fingerprint(otherSnippet))
+}
+
+func TestNormalizeSnippet(t *testing.T) {
+	assert.Equal(t, "import a.b\nimport c.d", NormalizeSnippet("+  import a.b\n\n-import   c.d"))
+	assert.Equal(t, "", NormalizeSnippet(""))
+	assert.Equal(t, "-- comment\n++i\n- item", NormalizeSnippet("+-- comment\n-++i\n+- item"))
+}
+
+func TestEngine_RunWithBaseline(t *testing.T) {
+	diff := `diff --git a/domain/model/Price.kt b/domain/model/Price.kt
+--- a/domain/model/Price.kt
++++ b/domain/model/Price.kt
+@@ -1,3 +1,5 @@
+ package domain.model
++val amount: Double = 0.0
++val tax: Double = 0.0`
+
+	rules := []config.Rule{
+		{
+			ID:          "money_minor_units",
+			Description: "Money must use int minor units",
+			Type:        "diff_pattern_forbidden",
+			Config: map[string]interface{}{
+				"forbidden_regexes": []interface{}{`\bDouble\b`},
+			},
+			Severity: "error",
+		},
+	}
+
+	e := NewEngine(rules, nil)
+	e.Baseline = []config.BaselineEntry{
+		NewBaselineEntry(Violation{
+			RuleID:      "money_minor_units",
+			FilePath:    "domain/model/Price.kt",
+			DiffSnippet: "+  val amount: Double = 0.0",
+		}),
+	}
+
+	result, err := e.Run(context.Background(), []string{"domain/model/Price.kt"}, diff)
+	require.NoError(t, err)
+
+	require.Len(t, result.Violations, 1)
+	assert.Contains(t, result.Violations[0].DiffSnippet, "tax")
+	require.Len(t, result.Baselined, 1)
+	assert.Contains(t, result.Baselined[0].DiffSnippet, "amount")
+	assert.Equal(t, 1, result.Errors)
+}
+
+func TestEngine_RunWithBaseline_Occurrences(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,6 @@
+ package main
++	fmt.Println(x)
++	fmt.Println(y)
++	fmt.Println(x)`
+
+	rules := []config.Rule{
+		{
+			ID:          "no_println",
+			Description: "Use the logger",
+			Type:        "diff_pattern_forbidden",
+			Config: map[string]interface{}{
+				"forbidden_regexes": []interface{}{`fmt\.Println`},
+			},
+			Severity: "error",
+		},
+	}
+	x := Violation{RuleID: "no_println", FilePath: "main.go", DiffSnippet: "+fmt.Println(x)"}
+
+	e := NewEngine(rules, nil)
+	e.Baseline = []config.BaselineEntry{NewBaselineEntry(x)}
+
+	result, err := e.Run(context.Background(), []string{"main.go"}, diff)
+	require.NoError(t, err)
+
+	// One baselined fmt.Println(x) does not cover a second one in the file.
+	require.Len(t, result.Baselined, 1)
+	assert.Equal(t, Fingerprint(x), Fingerprint(result.Baselined[0]))
+	require.Len(t, result.Violations, 2)
+	assert.ElementsMatch(t, []string{"fmt.Println(x)", "fmt.Println(y)"},
+		[]string{NormalizeSnippet(result.Violations[0].DiffSnippet), NormalizeSnippet(result.Violations[1].DiffSnippet)})
+
+	entry := NewBaselineEntry(x)
+	entry.Count = 2
+	e.Baseline = []config.BaselineEntry{entry}
+
+	result, err = e.Run(context.Background(), []string{"main.go"}, diff)
+	require.NoError(t, err)
+	assert.Len(t, result.Baselined, 2)
+	require.Len(t, result.Violations, 1)
+	assert.Contains(t, result.Violations[0].DiffSnippet, "fmt.Println(y)")
+}
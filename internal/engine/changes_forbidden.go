@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChangesForbiddenChecker checks that files matching paths are not changed in
+// any of the ways listed in change_kinds (e.g., deleting or renaming files
+// under migrations/). A file matches if either its old or its new path
+// matches, so moving a file out of a protected directory is caught as well.
+// Without change_kinds, any change to a matching file is a violation.
+type ChangesForbiddenChecker struct{}
+
+// Type returns the checker type identifier.
+func (c *ChangesForbiddenChecker) Type() string {
+	return "changes_forbidden"
+}
+
+// Check evaluates the changes_forbidden rule against the given context.
+func (c *ChangesForbiddenChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	paths, err := getStringSlice(cc.RuleConfig, "paths")
+	if err != nil {
+		return nil, fmt.Errorf("changes_forbidden: %w", err)
+	}
+
+	kinds, err := getChangeKinds(cc.RuleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("changes_forbidden: %w", err)
+	}
+
+	var violations []Violation
+	for _, fd := range cc.ParsedDiff().Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !kindAllowed(kinds, fd.Kind) {
+			continue
+		}
+		if !matchesAnyGlob(fd.OldPath, paths) && !matchesAnyGlob(fd.NewPath, paths) {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			RuleID:      cc.RuleID,
+			Severity:    cc.Severity,
+			Description: cc.RuleDesc,
+			FilePath:    fd.Path,
+			DiffSnippet: describeChange(fd),
+		})
+	}
+
+	return violations, nil
+}
+
+// describeChange returns a one-line summary of a file change, such as
+// "deleted migrations/001_init.sql" or "renamed a.go -> b.go".
+func describeChange(fd FileDiff) string {
+	switch fd.Kind {
+	case ChangeRenamed, ChangeCopied:
+		return fmt.Sprintf("%s %s -> %s", fd.Kind, fd.OldPath, fd.NewPath)
+	default:
+		return fmt.Sprintf("%s %s", fd.Kind, fd.Path)
+	}
+}
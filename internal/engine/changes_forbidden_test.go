@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationsDiff = `diff --git a/migrations/001_init.sql b/migrations/001_init.sql
+deleted file mode 100644
+--- a/migrations/001_init.sql
++++ /dev/null
+@@ -1 +0,0 @@
+-CREATE TABLE users (id INT);
+diff --git a/migrations/002_orders.sql b/archive/002_orders.sql
+similarity index 100%
+rename from migrations/002_orders.sql
+rename to archive/002_orders.sql
+diff --git a/migrations/003_items.sql b/migrations/003_items.sql
+new file mode 100644
+--- /dev/null
++++ b/migrations/003_items.sql
+@@ -0,0 +1 @@
++CREATE TABLE items (id INT);
+diff --git a/src/app.go b/src/app.go
+deleted file mode 100644
+--- a/src/app.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package src`
+
+func TestChangesForbidden_DeletionsAndRenames(t *testing.T) {
+	checker := &ChangesForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"migrations/001_init.sql", "archive/002_orders.sql", "migrations/003_items.sql", "src/app.go"},
+		DiffContent:  migrationsDiff,
+		RuleConfig: map[string]interface{}{
+			"paths":        []interface{}{"migrations/**"},
+			"change_kinds": []interface{}{"deleted", "renamed"},
+		},
+		Severity: "error",
+		RuleID:   "migrations_append_only",
+		RuleDesc: "Applied migrations must not be deleted or moved",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 2)
+	assert.Equal(t, "migrations/001_init.sql", violations[0].FilePath)
+	assert.Equal(t, "deleted migrations/001_init.sql", violations[0].DiffSnippet)
+	assert.Equal(t, "archive/002_orders.sql", violations[1].FilePath)
+	assert.Equal(t, "renamed migrations/002_orders.sql -> archive/002_orders.sql", violations[1].DiffSnippet)
+	assert.Equal(t, "migrations_append_only", violations[0].RuleID)
+	assert.Equal(t, "error", violations[0].Severity)
+}
+
+func TestChangesForbidden_AllKindsByDefault(t *testing.T) {
+	checker := &ChangesForbiddenChecker{}
+	ctx := &CheckContext{
+		DiffContent: migrationsDiff,
+		RuleConfig: map[string]interface{}{
+			"paths": []interface{}{"migrations/**"},
+		},
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Len(t, violations, 3)
+}
+
+func TestChangesForbidden_InvalidConfig(t *testing.T) {
+	checker := &ChangesForbiddenChecker{}
+
+	_, err := checker.Check(context.Background(), &CheckContext{RuleConfig: map[string]interface{}{}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "paths")
+
+	_, err = checker.Check(context.Background(), &CheckContext{RuleConfig: map[string]interface{}{
+		"paths":        []interface{}{"migrations/**"},
+		"change_kinds": []interface{}{"removed"},
+	}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "removed")
+}
+
+func TestChangesForbidden_Type(t *testing.T) {
+	checker := &ChangesForbiddenChecker{}
+	assert.Equal(t, "changes_forbidden", checker.Type())
+}
@@ -2,10 +2,18 @@
 // a registry of rule checkers, a unified orchestrator, and diff parsing utilities.
 package engine
 
+import (
+	"context"
+
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+)
+
 // RuleChecker is the interface that all rule type checkers must implement.
 type RuleChecker interface {
-	// Check evaluates the rule against the given context and returns any violations found.
-	Check(ctx *CheckContext) ([]Violation, error)
+	// Check evaluates the rule against the given check context and returns any
+	// violations found. Long-running checkers should return ctx.Err() once ctx
+	// is done; the engine stops waiting for them when the rule times out.
+	Check(ctx context.Context, cc *CheckContext) ([]Violation, error)
 	// Type returns the unique identifier for this checker type (e.g., "imports_forbidden").
 	Type() string
 }
@@ -14,10 +22,44 @@ type RuleChecker interface {
 type CheckContext struct {
 	ChangedFiles []string
 	DiffContent  string
-	RuleConfig   map[string]interface{}
-	Severity     string
-	RuleID       string
-	RuleDesc     string
+	// Diff is DiffContent parsed once by the engine and shared read-only
+	// between checkers. Use ParsedDiff to access it.
+	Diff       *ParsedDiff
+	RuleConfig map[string]interface{}
+	Severity   string
+	RuleID     string
+	RuleDesc   string
+	// Repo reads full file contents at the base and head revisions of the
+	// diff. It is nil when file contents are not available.
+	Repo RepoReader
+	// RepoRoot is the repository's top-level directory. Command rules run
+	// there; it is empty when unknown (commands then run in the current
+	// directory).
+	RepoRoot string
+	// Commits are the commits in the checked range, oldest first. It is
+	// empty when the check has no commit range (e.g., for staged changes).
+	Commits []git.Commit
+}
+
+// RepoReader gives checkers access to whole files on both sides of the diff.
+// Paths are slash-separated and relative to the repository root.
+// Implementations must be safe for concurrent use.
+type RepoReader interface {
+	// ReadBase returns the content of path before the change. It fails for
+	// files added by the diff.
+	ReadBase(path string) ([]byte, error)
+	// ReadHead returns the content of path after the change. It fails for
+	// files deleted by the diff.
+	ReadHead(path string) ([]byte, error)
+}
+
+// ParsedDiff returns the parsed diff shared by the engine, or parses
+// DiffContent if the context was built without one (e.g., in tests).
+func (cc *CheckContext) ParsedDiff() *ParsedDiff {
+	if cc.Diff != nil {
+		return cc.Diff
+	}
+	return NewParsedDiff(cc.DiffContent)
 }
 
 // Violation represents a single rule violation found during checking.
@@ -26,10 +68,22 @@ type Violation struct {
 	Severity       string `json:"severity"`
 	Description    string `json:"description"`
 	FilePath       string `json:"file_path"`
+	Line           int    `json:"line,omitempty"`     // first new-file line of the violation
+	EndLine        int    `json:"end_line,omitempty"` // last new-file line of the violation
+	Commit         string `json:"commit,omitempty"`   // SHA of the offending commit, for commit rules
 	DiffSnippet    string `json:"diff_snippet"`
 	LLMExplanation string `json:"llm_explanation"`
 }
 
+// RuleError records a rule that could not be evaluated, such as a rule with
+// an unknown type, an invalid regex or a missing config key. A failing rule
+// does not stop the other rules from running.
+type RuleError struct {
+	RuleID   string `json:"rule_id"`
+	RuleType string `json:"rule_type"`
+	Message  string `json:"error"`
+}
+
 // Registry maps rule type names to their corresponding checkers.
 var Registry = map[string]RuleChecker{}
 
@@ -42,4 +96,15 @@ func init() {
 	RegisterChecker(&ImportsForbiddenChecker{})
 	RegisterChecker(&DiffPatternForbiddenChecker{})
 	RegisterChecker(&DiffPatternRequiresChecker{})
+	RegisterChecker(&GoImportsForbiddenChecker{})
+	RegisterChecker(&LayersChecker{})
+	RegisterChecker(&CoChangeRequiredChecker{})
+	RegisterChecker(&ChangesForbiddenChecker{})
+	RegisterChecker(&CommandChecker{})
+	RegisterChecker(&SecretsForbiddenChecker{})
+	RegisterChecker(&DependencyPolicyChecker{})
+	RegisterChecker(&CommitMessageChecker{})
+	RegisterChecker(&AllOfChecker{})
+	RegisterChecker(&AnyOfChecker{})
+	RegisterChecker(&NotChecker{})
 }
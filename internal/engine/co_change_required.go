@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+)
+
+// CoChangeRequiredChecker checks that when files matching when_changed are
+// changed, at least one other file matching must_also_change is changed in
+// the same diff. With change_kind set to a change kind such as "added", the
+// companion change must be of that kind (e.g., a new migration); the default
+// "any" accepts any change.
+type CoChangeRequiredChecker struct{}
+
+// Type returns the checker type identifier.
+func (c *CoChangeRequiredChecker) Type() string {
+	return "co_change_required"
+}
+
+// Check evaluates the co_change_required rule against the given context.
+func (c *CoChangeRequiredChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	whenChanged, err := getStringSlice(cc.RuleConfig, "when_changed")
+	if err != nil {
+		return nil, fmt.Errorf("co_change_required: %w", err)
+	}
+
+	mustAlsoChange, err := getStringSlice(cc.RuleConfig, "must_also_change")
+	if err != nil {
+		return nil, fmt.Errorf("co_change_required: %w", err)
+	}
+
+	var changeKind ChangeKind
+	if v, ok := cc.RuleConfig["change_kind"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("co_change_required: change_kind must be a string, got %T", v)
+		}
+		if s != "any" {
+			changeKind, err = parseChangeKind(s)
+			if err != nil {
+				return nil, fmt.Errorf("co_change_required: change_kind: %w", err)
+			}
+		}
+	}
+
+	triggers := filterFilesByGlobs(cc.ChangedFiles, whenChanged)
+	if len(triggers) == 0 {
+		// Rule does not apply when no matching files are changed.
+		return nil, nil
+	}
+
+	triggerSet := make(map[string]bool, len(triggers))
+	for _, f := range triggers {
+		triggerSet[f] = true
+	}
+
+	ofKind := map[string]bool{}
+	if changeKind != "" {
+		for _, fd := range cc.ParsedDiff().Files {
+			if fd.Kind == changeKind {
+				ofKind[fd.Path] = true
+			}
+		}
+	}
+
+	for _, f := range filterFilesByGlobs(cc.ChangedFiles, mustAlsoChange) {
+		if triggerSet[f] {
+			continue // a file cannot satisfy its own co-change requirement
+		}
+		if changeKind != "" && !ofKind[f] {
+			continue
+		}
+		return nil, nil
+	}
+
+	return []Violation{
+		{
+			RuleID:      cc.RuleID,
+			Severity:    cc.Severity,
+			Description: cc.RuleDesc,
+			FilePath:    triggers[0],
+			DiffSnippet: "",
+		},
+	}, nil
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoChangeRequired_Satisfied(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"api/users/handler.go", "CHANGELOG.md"},
+		RuleConfig: map[string]interface{}{
+			"when_changed":     []interface{}{"api/**"},
+			"must_also_change": []interface{}{"CHANGELOG.md"},
+		},
+		Severity: "error",
+		RuleID:   "api_changelog",
+		RuleDesc: "API changes must update the changelog",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCoChangeRequired_Missing(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"README.md", "api/users/handler.go", "api/orders/handler.go"},
+		RuleConfig: map[string]interface{}{
+			"when_changed":     []interface{}{"api/**"},
+			"must_also_change": []interface{}{"CHANGELOG.md"},
+		},
+		Severity: "error",
+		RuleID:   "api_changelog",
+		RuleDesc: "API changes must update the changelog",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "api_changelog", violations[0].RuleID)
+	assert.Equal(t, "error", violations[0].Severity)
+	assert.Equal(t, "api/users/handler.go", violations[0].FilePath)
+}
+
+func TestCoChangeRequired_NotTriggered(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"web/index.ts"},
+		RuleConfig: map[string]interface{}{
+			"when_changed":     []interface{}{"api/**"},
+			"must_also_change": []interface{}{"CHANGELOG.md"},
+		},
+		RuleID: "api_changelog",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCoChangeRequired_TriggerDoesNotSatisfyItself(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"migrations/001_init.sql"},
+		RuleConfig: map[string]interface{}{
+			"when_changed":     []interface{}{"migrations/**"},
+			"must_also_change": []interface{}{"migrations/**", "docs/schema.md"},
+		},
+		RuleID: "migration_docs",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
+
+func TestCoChangeRequired_AddedFileRequired(t *testing.T) {
+	modifiedMigration := `diff --git a/db/schema.sql b/db/schema.sql
+--- a/db/schema.sql
++++ b/db/schema.sql
+@@ -1,1 +1,2 @@
+ CREATE TABLE users (id INT);
++CREATE TABLE orders (id INT);
+diff --git a/migrations/001_init.sql b/migrations/001_init.sql
+--- a/migrations/001_init.sql
++++ b/migrations/001_init.sql
+@@ -1,1 +1,2 @@
+ CREATE TABLE users (id INT);
++CREATE TABLE orders (id INT);`
+
+	addedMigration := `diff --git a/db/schema.sql b/db/schema.sql
+--- a/db/schema.sql
++++ b/db/schema.sql
+@@ -1,1 +1,2 @@
+ CREATE TABLE users (id INT);
++CREATE TABLE orders (id INT);
+diff --git a/migrations/002_orders.sql b/migrations/002_orders.sql
+new file mode 100644
+--- /dev/null
++++ b/migrations/002_orders.sql
+@@ -0,0 +1 @@
++CREATE TABLE orders (id INT);`
+
+	cfg := map[string]interface{}{
+		"when_changed":     []interface{}{"db/schema.sql"},
+		"must_also_change": []interface{}{"migrations/**"},
+		"change_kind":      "added",
+	}
+
+	checker := &CoChangeRequiredChecker{}
+
+	violations, err := checker.Check(context.Background(), &CheckContext{
+		ChangedFiles: []string{"db/schema.sql", "migrations/001_init.sql"},
+		DiffContent:  modifiedMigration,
+		RuleConfig:   cfg,
+		RuleID:       "schema_needs_migration",
+	})
+	require.NoError(t, err)
+	assert.Len(t, violations, 1, "modifying an existing migration is not enough")
+
+	violations, err = checker.Check(context.Background(), &CheckContext{
+		ChangedFiles: []string{"db/schema.sql", "migrations/002_orders.sql"},
+		DiffContent:  addedMigration,
+		RuleConfig:   cfg,
+		RuleID:       "schema_needs_migration",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCoChangeRequired_InvalidChangeKind(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"api/x.go"},
+		RuleConfig: map[string]interface{}{
+			"when_changed":     []interface{}{"api/**"},
+			"must_also_change": []interface{}{"CHANGELOG.md"},
+			"change_kind":      "removed",
+		},
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "change_kind")
+}
+
+func TestCoChangeRequired_MissingConfig(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	ctx := &CheckContext{
+		RuleConfig: map[string]interface{}{
+			"when_changed": []interface{}{"api/**"},
+		},
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must_also_change")
+}
+
+func TestCoChangeRequired_Type(t *testing.T) {
+	checker := &CoChangeRequiredChecker{}
+	assert.Equal(t, "co_change_required", checker.Type())
+}
@@ -0,0 +1,271 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+)
+
+// defaultCommandTimeout bounds a command rule that does not set its own
+// timeout, so that a hung script cannot block a check indefinitely.
+const defaultCommandTimeout = time.Minute
+
+// maxCommandStderr is the number of trailing stderr bytes quoted in the error
+// of a failed command.
+const maxCommandStderr = 500
+
+// CommandChecker runs an external executable as a rule. The rule config,
+// the changed files and the parsed diff are written to the command's stdin as
+// a CommandInput JSON document, and the command reports violations on stdout
+// as a CommandOutput JSON document. Exit code 0 means the output lists the
+// violations (an empty output means none); exit code 1 means violations were
+// found and the output must list them; any other exit code, a timeout or
+// unparsable output is a rule error. Commands run in the repository root.
+// The optional only_in_paths list limits the files sent to the command; the
+// command is not run when none of them changed.
+type CommandChecker struct{}
+
+// CommandInput is the JSON document written to a command rule's stdin.
+type CommandInput struct {
+	Rule         CommandRule            `json:"rule"`
+	Config       map[string]interface{} `json:"config"`
+	ChangedFiles []string               `json:"changed_files"`
+	Files        []CommandFile          `json:"files"`
+	Diff         string                 `json:"diff"`
+}
+
+// CommandRule identifies the rule a command is run for.
+type CommandRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+}
+
+// CommandFile is the parsed diff of a single file.
+type CommandFile struct {
+	Path    string        `json:"path"`
+	OldPath string        `json:"old_path,omitempty"`
+	NewPath string        `json:"new_path,omitempty"`
+	Kind    ChangeKind    `json:"kind"`
+	Binary  bool          `json:"binary,omitempty"`
+	Added   []CommandLine `json:"added"`
+	Removed []CommandLine `json:"removed"`
+}
+
+// CommandLine is a single added or removed line and its new-file line number.
+type CommandLine struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// CommandOutput is the JSON document a command rule writes to stdout.
+type CommandOutput struct {
+	Violations []CommandViolation `json:"violations"`
+}
+
+// CommandViolation is a single violation reported by a command. Severity and
+// rule ID always come from rules.yml; Message, if set, is appended to the
+// rule description.
+type CommandViolation struct {
+	FilePath    string `json:"file_path"`
+	Line        int    `json:"line,omitempty"`
+	EndLine     int    `json:"end_line,omitempty"`
+	Message     string `json:"message,omitempty"`
+	DiffSnippet string `json:"diff_snippet,omitempty"`
+}
+
+// Type returns the checker type identifier.
+func (c *CommandChecker) Type() string {
+	return "command"
+}
+
+// Check evaluates the command rule against the given context.
+func (c *CommandChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	argv, err := getStringSlice(cc.RuleConfig, "command")
+	if err != nil {
+		return nil, fmt.Errorf("command: %w", err)
+	}
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, fmt.Errorf("command: config key \"command\" must name an executable")
+	}
+
+	files := cc.ChangedFiles
+	if onlyInPaths, _ := getStringSlice(cc.RuleConfig, "only_in_paths"); len(onlyInPaths) > 0 {
+		files = filterFilesByGlobs(cc.ChangedFiles, onlyInPaths)
+		if len(files) == 0 {
+			return nil, nil
+		}
+	}
+
+	input, err := json.Marshal(buildCommandInput(cc, files))
+	if err != nil {
+		return nil, fmt.Errorf("command: encoding input: %w", err)
+	}
+
+	parent := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultCommandTimeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = cc.RepoRoot
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait forever for children that inherited the output pipes.
+	cmd.WaitDelay = time.Second
+
+	runErr := cmd.Run()
+	if ctx.Err() != nil {
+		if parent.Err() == nil {
+			return nil, fmt.Errorf("command: %s timed out after %s (set the rule's timeout to change this)", argv[0], defaultCommandTimeout)
+		}
+		return nil, ctx.Err()
+	}
+
+	exitCode := 0
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) {
+			return nil, fmt.Errorf("command: running %s: %w", argv[0], runErr)
+		}
+		exitCode = exitErr.ExitCode()
+	}
+	if exitCode != 0 && exitCode != 1 {
+		return nil, fmt.Errorf("command: %s exited with code %d%s", argv[0], exitCode, stderrSuffix(stderr.Bytes()))
+	}
+
+	if exitCode == 0 && len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, nil
+	}
+
+	var out CommandOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("command: parsing output of %s: %w%s", argv[0], err, stderrSuffix(stderr.Bytes()))
+	}
+	if exitCode == 1 && len(out.Violations) == 0 {
+		return nil, fmt.Errorf("command: %s exited with code 1 but reported no violations%s", argv[0], stderrSuffix(stderr.Bytes()))
+	}
+
+	violations := make([]Violation, 0, len(out.Violations))
+	for _, v := range out.Violations {
+		desc := cc.RuleDesc
+		if v.Message != "" {
+			desc = fmt.Sprintf("%s (%s)", cc.RuleDesc, v.Message)
+		}
+		endLine := v.EndLine
+		if endLine == 0 {
+			endLine = v.Line
+		}
+		violations = append(violations, Violation{
+			RuleID:      cc.RuleID,
+			Severity:    cc.Severity,
+			Description: desc,
+			FilePath:    v.FilePath,
+			Line:        v.Line,
+			EndLine:     endLine,
+			DiffSnippet: v.DiffSnippet,
+		})
+	}
+	return violations, nil
+}
+
+// CommandRulePaths returns the repository-relative files named in the command
+// lines of command rules, including those nested in composite rules (e.g.,
+// "scripts/check.py" in ["python3", "scripts/check.py"]), so that changing a
+// script requires an accepted proposal just like changing the rule itself.
+// Arguments without a "/", flags and absolute paths are skipped.
+func CommandRulePaths(rules []config.Rule) []string {
+	var paths []string
+	seen := map[string]bool{}
+	walkRules(rules, func(_ config.Rule, ruleType string, cfg map[string]interface{}) {
+		if ruleType != "command" {
+			return
+		}
+		argv, err := getStringSlice(cfg, "command")
+		if err != nil {
+			return
+		}
+		for _, arg := range argv {
+			if !strings.Contains(arg, "/") || strings.HasPrefix(arg, "-") || path.IsAbs(arg) {
+				continue
+			}
+			p := path.Clean(arg)
+			if strings.HasPrefix(p, "../") || seen[p] {
+				continue
+			}
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	})
+	return paths
+}
+
+// buildCommandInput assembles the stdin document for a command rule, limited
+// to the given changed files.
+func buildCommandInput(cc *CheckContext, files []string) CommandInput {
+	diff := cc.ParsedDiff()
+
+	input := CommandInput{
+		Rule: CommandRule{
+			ID:          cc.RuleID,
+			Description: cc.RuleDesc,
+			Severity:    cc.Severity,
+		},
+		Config:       cc.RuleConfig,
+		ChangedFiles: files,
+		Files:        make([]CommandFile, 0, len(files)),
+		Diff:         cc.DiffContent,
+	}
+	if input.ChangedFiles == nil {
+		input.ChangedFiles = []string{}
+	}
+
+	for _, path := range files {
+		fd, ok := diff.File(path)
+		if !ok {
+			continue
+		}
+		f := CommandFile{
+			Path:    fd.Path,
+			OldPath: fd.OldPath,
+			NewPath: fd.NewPath,
+			Kind:    fd.Kind,
+			Binary:  fd.IsBinary,
+			Added:   make([]CommandLine, 0, len(fd.AddedLines)),
+			Removed: make([]CommandLine, 0, len(fd.RemovedLines)),
+		}
+		for i, l := range fd.AddedLines {
+			f.Added = append(f.Added, CommandLine{Line: lineAt(fd.AddedLineNos, i), Text: l})
+		}
+		for i, l := range fd.RemovedLines {
+			f.Removed = append(f.Removed, CommandLine{Line: lineAt(fd.RemovedLineNos, i), Text: l})
+		}
+		input.Files = append(input.Files, f)
+	}
+	return input
+}
+
+// stderrSuffix formats the tail of a command's stderr for an error message,
+// or returns "" if stderr is empty.
+func stderrSuffix(stderr []byte) string {
+	s := strings.TrimSpace(string(stderr))
+	if s == "" {
+		return ""
+	}
+	if len(s) > maxCommandStderr {
+		s = "..." + s[len(s)-maxCommandStderr:]
+	}
+	return ": " + s
+}
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commandTestDiff = `diff --git a/src/app.py b/src/app.py
+--- a/src/app.py
++++ b/src/app.py
+@@ -1,2 +1,3 @@
+ import os
++password = "hunter2"
+ print(os.getcwd())
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1,2 @@
+ # App
++Docs.
+`
+
+// writeScript writes an executable shell script into dir and returns its path.
+func writeScript(t *testing.T, dir, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("command rule tests use /bin/sh scripts")
+	}
+	path := filepath.Join(dir, "check.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755))
+	return path
+}
+
+func commandContext(cfg map[string]interface{}, root string) *CheckContext {
+	return &CheckContext{
+		ChangedFiles: []string{"src/app.py", "README.md"},
+		DiffContent:  commandTestDiff,
+		RuleConfig:   cfg,
+		Severity:     "error",
+		RuleID:       "security_scan",
+		RuleDesc:     "Security team checks",
+		RepoRoot:     root,
+	}
+}
+
+func TestCommand_ReportsViolations(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, `cat > /dev/null
+echo '{"violations":[{"file_path":"src/app.py","line":2,"message":"hardcoded password","diff_snippet":"+password = \"hunter2\""}]}'
+exit 1
+`)
+
+	checker := &CommandChecker{}
+	violations, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{script},
+	}, dir))
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	v := violations[0]
+	assert.Equal(t, "security_scan", v.RuleID)
+	assert.Equal(t, "error", v.Severity)
+	assert.Equal(t, "Security team checks (hardcoded password)", v.Description)
+	assert.Equal(t, "src/app.py", v.FilePath)
+	assert.Equal(t, 2, v.Line)
+	assert.Equal(t, 2, v.EndLine)
+	assert.Contains(t, v.DiffSnippet, "hunter2")
+}
+
+func TestCommand_ReceivesInputAndRunsInRepoRoot(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, `cat > input.json
+`)
+
+	checker := &CommandChecker{}
+	violations, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command":       []interface{}{script},
+		"only_in_paths": []interface{}{"src/**"},
+		"max_findings":  3,
+	}, dir))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	data, err := os.ReadFile(filepath.Join(dir, "input.json"))
+	require.NoError(t, err)
+
+	var input CommandInput
+	require.NoError(t, json.Unmarshal(data, &input))
+	assert.Equal(t, "security_scan", input.Rule.ID)
+	assert.Equal(t, "error", input.Rule.Severity)
+	assert.Equal(t, float64(3), input.Config["max_findings"])
+	assert.Equal(t, []string{"src/app.py"}, input.ChangedFiles)
+	require.Len(t, input.Files, 1)
+	assert.Equal(t, "src/app.py", input.Files[0].Path)
+	assert.Equal(t, ChangeModified, input.Files[0].Kind)
+	assert.Equal(t, []CommandLine{{Line: 2, Text: `password = "hunter2"`}}, input.Files[0].Added)
+	assert.Contains(t, input.Diff, "diff --git a/src/app.py")
+}
+
+func TestCommand_SkipsWhenNoFilesMatch(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "exit 3\n")
+
+	checker := &CommandChecker{}
+	violations, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command":       []interface{}{script},
+		"only_in_paths": []interface{}{"infra/**"},
+	}, dir))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCommand_ExitCodeTwoIsError(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, `echo "config file missing" >&2
+exit 2
+`)
+
+	checker := &CommandChecker{}
+	_, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{script},
+	}, dir))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exited with code 2")
+	assert.Contains(t, err.Error(), "config file missing")
+}
+
+func TestCommand_ExitOneWithoutViolationsIsError(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, `echo "Traceback (most recent call last)" >&2
+exit 1
+`)
+
+	checker := &CommandChecker{}
+	_, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{script},
+	}, dir))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Traceback")
+}
+
+func TestCommand_InvalidOutput(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "echo 'all good'\n")
+
+	checker := &CommandChecker{}
+	_, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{script},
+	}, dir))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing output")
+}
+
+func TestCommand_KilledOnTimeout(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, "sleep 10\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	checker := &CommandChecker{}
+	_, err := checker.Check(ctx, commandContext(map[string]interface{}{
+		"command": []interface{}{script},
+	}, dir))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCommand_MissingExecutable(t *testing.T) {
+	checker := &CommandChecker{}
+	_, err := checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{"/nonexistent/guardian-check"},
+	}, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "running /nonexistent/guardian-check")
+}
+
+func TestCommand_MissingConfig(t *testing.T) {
+	checker := &CommandChecker{}
+	_, err := checker.Check(context.Background(), commandContext(map[string]interface{}{}, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "command")
+
+	_, err = checker.Check(context.Background(), commandContext(map[string]interface{}{
+		"command": []interface{}{},
+	}, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must name an executable")
+}
+
+func TestCommand_Type(t *testing.T) {
+	checker := &CommandChecker{}
+	assert.Equal(t, "command", checker.Type())
+}
+
+func TestCommandRulePaths(t *testing.T) {
+	rules := []config.Rule{
+		{ID: "scan", Type: "command", Config: map[string]interface{}{
+			"command": []interface{}{"python3", "./security/scan.py", "--config=security/scan.yml", "/usr/bin/env"},
+		}},
+		{ID: "lint", Type: "command", Config: map[string]interface{}{
+			"command": []interface{}{"tools/lint.sh", "security/scan.py"},
+		}},
+		{ID: "other", Type: "diff_pattern_forbidden", Config: map[string]interface{}{
+			"command": []interface{}{"ignored/script.sh"},
+		}},
+		{ID: "broken", Type: "command"},
+		{ID: "nested", Type: "any_of", Config: map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{"type": "not", "config": map[string]interface{}{
+					"rule": map[string]interface{}{"type": "command", "config": map[string]interface{}{
+						"command": []interface{}{"sh", "tools/nested.sh"},
+					}},
+				}},
+				"not a rule",
+			},
+		}},
+	}
+
+	assert.Equal(t, []string{"security/scan.py", "tools/lint.sh", "tools/nested.sh"}, CommandRulePaths(rules))
+}
@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+)
+
+// CommitMessageChecker enforces conventions on the messages of the commits in
+// the checked range rather than on the diff: a subject_regex the subject must
+// match (e.g., Conventional Commits), a max_subject_length, required_trailers
+// such as "Signed-off-by" or "Refs: ^[A-Z]+-[0-9]+$" (a trailer key,
+// optionally followed by a regex its value must match), and forbid_fixup,
+// which rejects fixup!, squash!, amend! and work-in-progress commits. Each
+// violation names the offending commit. Merge commits are not checked, and
+// a check without a commit range (e.g., of staged changes) reports nothing.
+type CommitMessageChecker struct{}
+
+// commitPolicy is the parsed configuration of a commit_message rule.
+type commitPolicy struct {
+	subject      *regexp.Regexp
+	maxSubject   int
+	trailers     []requiredTrailer
+	forbidFixups bool
+}
+
+// requiredTrailer is an entry of required_trailers.
+type requiredTrailer struct {
+	Key   string
+	Value *regexp.Regexp // nil if any value will do
+}
+
+// fixupPrefixes start the subjects of commits made to be squashed away by
+// git rebase --autosquash.
+var fixupPrefixes = []string{"fixup!", "squash!", "amend!"}
+
+// wipSubject matches the subjects of work-in-progress commits, e.g., "WIP",
+// "wip: parser" or "[WIP] parser".
+var wipSubject = regexp.MustCompile(`(?i)^\W*wip\b`)
+
+// NeedsCommits reports whether checking the rules needs the commits of the
+// range: an enabled rule is a commit_message rule or nests one in a
+// composite rule, or the identity settings have checks.
+func NeedsCommits(rules []config.Rule, identity config.Identity) bool {
+	needed := identity.HasChecks()
+	walkRules(rules, func(top config.Rule, ruleType string, _ map[string]interface{}) {
+		if top.IsEnabled() && ruleType == "commit_message" {
+			needed = true
+		}
+	})
+	return needed
+}
+
+// Type returns the checker type identifier.
+func (c *CommitMessageChecker) Type() string {
+	return "commit_message"
+}
+
+// Check evaluates the commit_message rule against the given context.
+func (c *CommitMessageChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	policy, err := parseCommitPolicy(cc.RuleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("commit_message: %w", err)
+	}
+
+	var violations []Violation
+	for _, commit := range cc.Commits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if commit.IsMerge() {
+			continue
+		}
+
+		for _, reason := range policy.check(commit) {
+			violations = append(violations, Violation{
+				RuleID:      cc.RuleID,
+				Severity:    cc.Severity,
+				Description: fmt.Sprintf("%s (%s)", cc.RuleDesc, reason),
+				Commit:      commit.SHA,
+				DiffSnippet: commit.Subject,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// parseCommitPolicy reads and validates a commit_message rule's config.
+func parseCommitPolicy(cfg map[string]interface{}) (*commitPolicy, error) {
+	policy := &commitPolicy{}
+
+	if val, ok := cfg["subject_regex"]; ok {
+		pattern, ok := val.(string)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("config key %q: expected a non-empty string, got %v", "subject_regex", val)
+		}
+		re, err := compileRegex(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject_regex %q: %w", pattern, err)
+		}
+		policy.subject = re
+	}
+
+	maxSubject, err := getNumber(cfg, "max_subject_length", 0)
+	if err != nil {
+		return nil, err
+	}
+	if maxSubject < 0 || maxSubject != math.Trunc(maxSubject) {
+		return nil, fmt.Errorf("config key %q: expected a positive integer, got %v", "max_subject_length", maxSubject)
+	}
+	policy.maxSubject = int(maxSubject)
+
+	if _, ok := cfg["required_trailers"]; ok {
+		entries, err := getStringSlice(cfg, "required_trailers")
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			trailer, err := parseRequiredTrailer(entry)
+			if err != nil {
+				return nil, err
+			}
+			policy.trailers = append(policy.trailers, trailer)
+		}
+	}
+
+	if policy.forbidFixups, err = getBool(cfg, "forbid_fixup"); err != nil {
+		return nil, err
+	}
+
+	if policy.subject == nil && policy.maxSubject == 0 && len(policy.trailers) == 0 && !policy.forbidFixups {
+		return nil, fmt.Errorf("at least one of subject_regex, max_subject_length, required_trailers or forbid_fixup is required")
+	}
+	return policy, nil
+}
+
+// parseRequiredTrailer parses a required_trailers entry of the form "Key" or
+// "Key: value-regex".
+func parseRequiredTrailer(entry string) (requiredTrailer, error) {
+	key, pattern, hasValue := strings.Cut(entry, ":")
+	trailer := requiredTrailer{Key: strings.TrimSpace(key)}
+	if trailer.Key == "" || strings.ContainsAny(trailer.Key, " \t") {
+		return trailer, fmt.Errorf("required_trailers: invalid trailer %q", entry)
+	}
+
+	pattern = strings.TrimSpace(pattern)
+	if hasValue && pattern != "" {
+		re, err := compileRegex(pattern)
+		if err != nil {
+			return trailer, fmt.Errorf("required_trailers: invalid regex %q for %s: %w", pattern, trailer.Key, err)
+		}
+		trailer.Value = re
+	}
+	return trailer, nil
+}
+
+// check returns the reasons, if any, why the commit breaks the policy.
+func (p *commitPolicy) check(commit git.Commit) []string {
+	var reasons []string
+
+	if p.forbidFixups {
+		if prefix := fixupPrefix(commit.Subject); prefix != "" {
+			reasons = append(reasons, fmt.Sprintf("%s commit must be squashed before merging", prefix))
+		} else if wipSubject.MatchString(commit.Subject) {
+			reasons = append(reasons, "work-in-progress commit")
+		}
+	}
+
+	if p.subject != nil && !p.subject.MatchString(commit.Subject) {
+		reasons = append(reasons, fmt.Sprintf("subject does not match %s", p.subject))
+	}
+
+	if n := utf8.RuneCountInString(commit.Subject); p.maxSubject > 0 && n > p.maxSubject {
+		reasons = append(reasons, fmt.Sprintf("subject is %d characters long, more than %d", n, p.maxSubject))
+	}
+
+	for _, required := range p.trailers {
+		if hasTrailer(commit.Trailers, required) {
+			continue
+		}
+		if required.Value != nil {
+			reasons = append(reasons, fmt.Sprintf("missing %s trailer matching %s", required.Key, required.Value))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("missing %s trailer", required.Key))
+		}
+	}
+
+	return reasons
+}
+
+// fixupPrefix returns the autosquash prefix of subject without its "!", or
+// "" if it has none.
+func fixupPrefix(subject string) string {
+	for _, prefix := range fixupPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return strings.TrimSuffix(prefix, "!")
+		}
+	}
+	return ""
+}
+
+// hasTrailer reports whether trailers include the required one. Trailer keys
+// are compared case-insensitively, as git does.
+func hasTrailer(trailers []git.Trailer, required requiredTrailer) bool {
+	for _, t := range trailers {
+		if !strings.EqualFold(t.Key, required.Key) {
+			continue
+		}
+		if required.Value == nil || required.Value.MatchString(t.Value) {
+			return true
+		}
+	}
+	return false
+}
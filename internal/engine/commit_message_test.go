@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCommits = []git.Commit{
+	{
+		SHA:      "1111111111111111111111111111111111111111",
+		Subject:  "feat(orders): add the orders endpoint",
+		Trailers: []git.Trailer{{Key: "Refs", Value: "SHOP-12"}, {Key: "signed-off-by", Value: "Dev <dev@example.com>"}},
+	},
+	{
+		SHA:      "2222222222222222222222222222222222222222",
+		Subject:  "Update stuff in the orders service and also the billing service",
+		Trailers: []git.Trailer{{Key: "Refs", Value: "none"}},
+	},
+	{
+		SHA:     "3333333333333333333333333333333333333333",
+		Subject: "fixup! feat(orders): add the orders endpoint",
+	},
+	{
+		SHA:     "4444444444444444444444444444444444444444",
+		Subject: "[WIP] fix: totals",
+	},
+	{
+		SHA:     "5555555555555555555555555555555555555555",
+		Parents: []string{"1111111111111111111111111111111111111111", "6666666666666666666666666666666666666666"},
+		Subject: "Merge branch 'main' into orders",
+	},
+}
+
+func checkCommits(t *testing.T, cfg map[string]interface{}, commits []git.Commit) ([]Violation, error) {
+	t.Helper()
+	return (&CommitMessageChecker{}).Check(context.Background(), &CheckContext{
+		RuleConfig: cfg,
+		Severity:   "error",
+		RuleID:     "commits",
+		RuleDesc:   "Commits follow the conventions",
+		Commits:    commits,
+	})
+}
+
+func TestCommitMessage_Conventions(t *testing.T) {
+	violations, err := checkCommits(t, map[string]interface{}{
+		"subject_regex":      `^(feat|fix|chore)(\([a-z]+\))?: `,
+		"max_subject_length": 50,
+		"required_trailers":  []interface{}{"Signed-off-by", "Refs: ^[A-Z]+-[0-9]+$"},
+		"forbid_fixup":       true,
+	}, testCommits)
+	require.NoError(t, err)
+
+	var got [][2]string
+	for _, v := range violations {
+		got = append(got, [2]string{v.Commit[:1], v.Description})
+		assert.Empty(t, v.FilePath)
+	}
+	assert.Equal(t, [][2]string{
+		{"2", "Commits follow the conventions (subject does not match ^(feat|fix|chore)(\\([a-z]+\\))?: )"},
+		{"2", "Commits follow the conventions (subject is 63 characters long, more than 50)"},
+		{"2", "Commits follow the conventions (missing Signed-off-by trailer)"},
+		{"2", "Commits follow the conventions (missing Refs trailer matching ^[A-Z]+-[0-9]+$)"},
+		{"3", "Commits follow the conventions (fixup commit must be squashed before merging)"},
+		{"3", "Commits follow the conventions (subject does not match ^(feat|fix|chore)(\\([a-z]+\\))?: )"},
+		{"3", "Commits follow the conventions (missing Signed-off-by trailer)"},
+		{"3", "Commits follow the conventions (missing Refs trailer matching ^[A-Z]+-[0-9]+$)"},
+		{"4", "Commits follow the conventions (work-in-progress commit)"},
+		{"4", "Commits follow the conventions (subject does not match ^(feat|fix|chore)(\\([a-z]+\\))?: )"},
+		{"4", "Commits follow the conventions (missing Signed-off-by trailer)"},
+		{"4", "Commits follow the conventions (missing Refs trailer matching ^[A-Z]+-[0-9]+$)"},
+	}, got, "the first commit passes and the merge commit is skipped")
+	assert.Equal(t, testCommits[1].SHA, violations[0].Commit)
+	assert.Equal(t, testCommits[1].Subject, violations[0].DiffSnippet)
+}
+
+func TestCommitMessage_FixupOnly(t *testing.T) {
+	commits := []git.Commit{
+		{SHA: "a", Subject: "squash! parser"},
+		{SHA: "b", Subject: "amend! parser"},
+		{SHA: "c", Subject: "wip: parser"},
+		{SHA: "d", Subject: "Wipe the cache on logout"},
+	}
+	violations, err := checkCommits(t, map[string]interface{}{"forbid_fixup": true}, commits)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Commits follow the conventions (squash commit must be squashed before merging)",
+		"Commits follow the conventions (amend commit must be squashed before merging)",
+		"Commits follow the conventions (work-in-progress commit)",
+	}, descriptions(violations))
+}
+
+func TestCommitMessage_NoCommits(t *testing.T) {
+	violations, err := checkCommits(t, map[string]interface{}{"forbid_fixup": true}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCommitMessage_ConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  map[string]interface{}
+		want string
+	}{
+		{"no policy", map[string]interface{}{}, "at least one of subject_regex, max_subject_length, required_trailers or forbid_fixup is required"},
+		{"bad regex", map[string]interface{}{"subject_regex": "[feat"}, `invalid subject_regex "[feat"`},
+		{"bad length", map[string]interface{}{"max_subject_length": 72.5}, `config key "max_subject_length": expected a positive integer`},
+		{"bad trailers", map[string]interface{}{"required_trailers": "Signed-off-by"}, `config key "required_trailers": expected string slice`},
+		{"bad trailer key", map[string]interface{}{"required_trailers": []interface{}{"Signed off by"}}, `required_trailers: invalid trailer "Signed off by"`},
+		{"bad trailer regex", map[string]interface{}{"required_trailers": []interface{}{"Refs: [A-Z"}}, `required_trailers: invalid regex "[A-Z" for Refs`},
+		{"bad bool", map[string]interface{}{"forbid_fixup": "yes"}, `config key "forbid_fixup": expected a boolean`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkCommits(t, tt.cfg, testCommits)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "commit_message: "+tt.want)
+		})
+	}
+}
+
+func TestEngine_PassesCommits(t *testing.T) {
+	eng := NewEngine([]config.Rule{{
+		ID:          "no_fixups",
+		Description: "No fixup commits",
+		Type:        "commit_message",
+		Config:      map[string]interface{}{"forbid_fixup": true},
+		Severity:    "error",
+	}}, nil)
+	eng.Commits = testCommits
+
+	result, err := eng.Run(context.Background(), nil, "")
+	require.NoError(t, err)
+	require.Len(t, result.Violations, 2)
+	assert.Equal(t, testCommits[2].SHA, result.Violations[0].Commit)
+	assert.Equal(t, 2, result.Errors)
+}
+
+func TestNeedsCommits(t *testing.T) {
+	commitRule := config.Rule{ID: "commits", Type: "commit_message", Config: map[string]interface{}{"forbid_fixup": true}}
+	nested := config.Rule{ID: "src_refs", Type: "all_of", Config: map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"type": "not", "config": map[string]interface{}{
+				"rule": map[string]interface{}{"type": "commit_message", "config": map[string]interface{}{"forbid_fixup": true}},
+			}},
+		},
+	}}
+	other := config.Rule{ID: "money", Type: "diff_pattern_forbidden"}
+	disabled := nested
+	disabled.Enabled = new(bool)
+
+	assert.True(t, NeedsCommits([]config.Rule{other, commitRule}, config.Identity{}))
+	assert.True(t, NeedsCommits([]config.Rule{other, nested}, config.Identity{}))
+	assert.False(t, NeedsCommits([]config.Rule{other, disabled}, config.Identity{}))
+	assert.True(t, NeedsCommits([]config.Rule{other}, config.Identity{RequireSignedCommits: true}))
+	assert.False(t, NeedsCommits(nil, config.Identity{}))
+}
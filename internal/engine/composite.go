@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+)
+
+// Composite rule types combine other rules, evaluated file by file:
+//
+//   - all_of reports a file if every nested rule reports it
+//   - any_of reports a file if at least one nested rule reports it
+//   - not reports a file if its nested rule does not report it
+//
+// Nested rules are written as {type, config} mappings under the "rules" key
+// (all_of, any_of) or the "rule" key (not) and may use any registered type,
+// including other composites. Each nested rule runs once per changed file
+// with a context holding only that file, so diff-wide types such as
+// diff_pattern_requires are evaluated per file as well. Violations carry the
+// composite rule's ID, severity and description. The optional only_in_paths
+// list limits the files considered.
+type (
+	// AllOfChecker implements the all_of composite rule type.
+	AllOfChecker struct{}
+	// AnyOfChecker implements the any_of composite rule type.
+	AnyOfChecker struct{}
+	// NotChecker implements the not composite rule type.
+	NotChecker struct{}
+)
+
+// subRule is a rule nested in a composite rule.
+type subRule struct {
+	Type    string
+	Config  map[string]interface{}
+	checker RuleChecker
+}
+
+// Type returns the checker type identifier.
+func (c *AllOfChecker) Type() string {
+	return "all_of"
+}
+
+// Check evaluates the all_of rule against the given context.
+func (c *AllOfChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	rules, err := getSubRules(cc.RuleConfig, "rules")
+	if err != nil {
+		return nil, fmt.Errorf("all_of: %w", err)
+	}
+
+	return forEachFile(ctx, cc, func(file string) ([]Violation, bool, error) {
+		var collected []Violation
+		for i, rule := range rules {
+			violations, fired, err := rule.checkFile(ctx, cc, file)
+			if err != nil {
+				return nil, false, fmt.Errorf("all_of: rules[%d] (%s): %w", i, rule.Type, err)
+			}
+			if !fired {
+				return nil, false, nil
+			}
+			if rule.Type != "not" {
+				collected = append(collected, violations...)
+			}
+		}
+		return collected, true, nil
+	})
+}
+
+// Type returns the checker type identifier.
+func (c *AnyOfChecker) Type() string {
+	return "any_of"
+}
+
+// Check evaluates the any_of rule against the given context.
+func (c *AnyOfChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	rules, err := getSubRules(cc.RuleConfig, "rules")
+	if err != nil {
+		return nil, fmt.Errorf("any_of: %w", err)
+	}
+
+	return forEachFile(ctx, cc, func(file string) ([]Violation, bool, error) {
+		var collected []Violation
+		anyFired := false
+		for i, rule := range rules {
+			violations, fired, err := rule.checkFile(ctx, cc, file)
+			if err != nil {
+				return nil, false, fmt.Errorf("any_of: rules[%d] (%s): %w", i, rule.Type, err)
+			}
+			if fired {
+				anyFired = true
+				if rule.Type != "not" {
+					collected = append(collected, violations...)
+				}
+			}
+		}
+		return collected, anyFired, nil
+	})
+}
+
+// Type returns the checker type identifier.
+func (c *NotChecker) Type() string {
+	return "not"
+}
+
+// Check evaluates the not rule against the given context.
+func (c *NotChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	val, ok := cc.RuleConfig["rule"]
+	if !ok {
+		return nil, fmt.Errorf("not: missing config key %q", "rule")
+	}
+	rule, err := parseSubRule(val)
+	if err != nil {
+		return nil, fmt.Errorf("not: config key \"rule\": %w", err)
+	}
+
+	return forEachFile(ctx, cc, func(file string) ([]Violation, bool, error) {
+		_, fired, err := rule.checkFile(ctx, cc, file)
+		if err != nil {
+			return nil, false, fmt.Errorf("not: rule (%s): %w", rule.Type, err)
+		}
+		return nil, !fired, nil
+	})
+}
+
+// forEachFile evaluates a composite rule on each changed file (limited by
+// only_in_paths). eval returns the violations found in the file and whether
+// the composite reports the file at all; a reported file without violations
+// (e.g., from not) yields a single violation against the whole file.
+func forEachFile(ctx context.Context, cc *CheckContext, eval func(file string) ([]Violation, bool, error)) ([]Violation, error) {
+	files := cc.ChangedFiles
+	if onlyInPaths, _ := getStringSlice(cc.RuleConfig, "only_in_paths"); len(onlyInPaths) > 0 {
+		files = filterFilesByGlobs(cc.ChangedFiles, onlyInPaths)
+	}
+
+	var violations []Violation
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		found, reported, err := eval(file)
+		if err != nil {
+			return nil, err
+		}
+		if !reported {
+			continue
+		}
+		if len(found) == 0 {
+			found = []Violation{{
+				RuleID:      cc.RuleID,
+				Severity:    cc.Severity,
+				Description: cc.RuleDesc,
+				FilePath:    file,
+			}}
+		}
+		violations = append(violations, found...)
+	}
+	// A nested rule on commits (commit_message) reports the same commits
+	// for every file.
+	return dedupeViolations(violations), nil
+}
+
+// checkFile runs the nested rule against a single file and reports whether it
+// found any violation there. Violations take the composite rule's ID,
+// severity and description.
+func (r subRule) checkFile(ctx context.Context, cc *CheckContext, file string) ([]Violation, bool, error) {
+	diff := cc.ParsedDiff()
+	fileCtx := &CheckContext{
+		ChangedFiles: []string{file},
+		DiffContent:  cc.DiffContent,
+		Diff:         diff.Only(file),
+		RuleConfig:   r.Config,
+		Severity:     cc.Severity,
+		RuleID:       cc.RuleID,
+		RuleDesc:     cc.RuleDesc,
+		Repo:         cc.Repo,
+		RepoRoot:     cc.RepoRoot,
+		Commits:      cc.Commits,
+	}
+
+	violations, err := r.checker.Check(ctx, fileCtx)
+	if err != nil {
+		return nil, false, err
+	}
+	return violations, len(violations) > 0, nil
+}
+
+// dedupeViolations drops violations reported for the same location (or
+// commit) more than once, e.g., by more than one nested rule.
+func dedupeViolations(violations []Violation) []Violation {
+	type location struct {
+		path    string
+		line    int
+		commit  string
+		snippet string
+	}
+	seen := make(map[location]bool, len(violations))
+	result := make([]Violation, 0, len(violations))
+	for _, v := range violations {
+		key := location{v.FilePath, v.Line, v.Commit, v.DiffSnippet}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// getSubRules reads a non-empty list of nested rules from a composite rule's
+// config.
+func getSubRules(cfg map[string]interface{}, key string) ([]subRule, error) {
+	val, ok := cfg[key]
+	if !ok {
+		return nil, fmt.Errorf("missing config key %q", key)
+	}
+	items, ok := val.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("config key %q: expected a non-empty list of rules", key)
+	}
+
+	rules := make([]subRule, 0, len(items))
+	for i, item := range items {
+		rule, err := parseSubRule(item)
+		if err != nil {
+			return nil, fmt.Errorf("config key %q: rules[%d]: %w", key, i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseSubRule parses a nested {type, config} mapping and looks up its
+// checker in the registry.
+func parseSubRule(val interface{}) (subRule, error) {
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return subRule{}, fmt.Errorf("expected mapping with type and config, got %T", val)
+	}
+
+	typ, ok := m["type"].(string)
+	if !ok || typ == "" {
+		return subRule{}, fmt.Errorf("missing rule type")
+	}
+	checker, ok := Registry[typ]
+	if !ok {
+		return subRule{}, fmt.Errorf("unknown rule type %q", typ)
+	}
+
+	cfg := map[string]interface{}{}
+	if c, ok := m["config"]; ok && c != nil {
+		cfg, ok = c.(map[string]interface{})
+		if !ok {
+			return subRule{}, fmt.Errorf("config of %s rule: expected mapping, got %T", typ, c)
+		}
+	}
+
+	return subRule{Type: typ, Config: cfg, checker: checker}, nil
+}
+
+// walkRules calls fn for each rule and, depth first, for every rule nested
+// in it by a composite rule, with the top-level rule it belongs to. Nested
+// rules that cannot be parsed are skipped; checking reports them.
+func walkRules(rules []config.Rule, fn func(top config.Rule, ruleType string, cfg map[string]interface{})) {
+	for _, rule := range rules {
+		walkRule(rule.Type, rule.Config, func(ruleType string, cfg map[string]interface{}) {
+			fn(rule, ruleType, cfg)
+		})
+	}
+}
+
+// walkRule calls fn for a rule and every rule nested in it.
+func walkRule(ruleType string, cfg map[string]interface{}, fn func(ruleType string, cfg map[string]interface{})) {
+	fn(ruleType, cfg)
+
+	var nested []interface{}
+	switch ruleType {
+	case "all_of", "any_of":
+		nested, _ = cfg["rules"].([]interface{})
+	case "not":
+		nested = []interface{}{cfg["rule"]}
+	}
+	for _, val := range nested {
+		if rule, err := parseSubRule(val); err == nil {
+			walkRule(rule.Type, rule.Config, fn)
+		}
+	}
+}
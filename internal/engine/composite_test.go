@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const compositeTestDiff = `diff --git a/src/Report.kt b/src/Report.kt
+--- a/src/Report.kt
++++ b/src/Report.kt
+@@ -1,2 +1,4 @@
+ fun report() {
++    println("total")
++    println("done")
+ }
+diff --git a/src/Debug.kt b/src/Debug.kt
+--- a/src/Debug.kt
++++ b/src/Debug.kt
+@@ -1,2 +1,4 @@
+ fun debug() {
++    // debug-ok
++    println("state")
+ }
+diff --git a/lib/Util.kt b/lib/Util.kt
+--- a/lib/Util.kt
++++ b/lib/Util.kt
+@@ -1,2 +1,3 @@
+ fun util() {
++    println("util")
+ }
+`
+
+var compositeTestFiles = []string{"src/Report.kt", "src/Debug.kt", "lib/Util.kt"}
+
+// compositeConfig parses a rule config written in YAML, so that nested rules
+// have the same types as when loaded from rules.yml.
+func compositeConfig(t *testing.T, src string) map[string]interface{} {
+	t.Helper()
+	var cfg map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(src), &cfg))
+	return cfg
+}
+
+func compositeCheck(t *testing.T, checker RuleChecker, cfg string) ([]Violation, error) {
+	t.Helper()
+	return checker.Check(context.Background(), &CheckContext{
+		ChangedFiles: compositeTestFiles,
+		DiffContent:  compositeTestDiff,
+		RuleConfig:   compositeConfig(t, cfg),
+		Severity:     "error",
+		RuleID:       "no_println",
+		RuleDesc:     "No println unless marked debug-ok",
+	})
+}
+
+func TestAllOf_ForbidUnlessMarked(t *testing.T) {
+	violations, err := compositeCheck(t, &AllOfChecker{}, `
+rules:
+  - type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["println"]
+      only_in_paths: ["src/**"]
+  - type: not
+    config:
+      rule:
+        type: diff_pattern_forbidden
+        config:
+          forbidden_regexes: ["// debug-ok"]
+`)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 2)
+	for _, v := range violations {
+		assert.Equal(t, "no_println", v.RuleID)
+		assert.Equal(t, "error", v.Severity)
+		assert.Equal(t, "No println unless marked debug-ok", v.Description)
+		assert.Equal(t, "src/Report.kt", v.FilePath)
+	}
+	assert.Equal(t, 2, violations[0].Line)
+	assert.Equal(t, 3, violations[1].Line)
+}
+
+func TestAllOf_PerFileRequires(t *testing.T) {
+	// diff_pattern_requires normally looks at the whole diff; nested, it is
+	// evaluated for each file on its own.
+	violations, err := compositeCheck(t, &AllOfChecker{}, `
+rules:
+  - type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["println"]
+  - type: diff_pattern_requires
+    config:
+      required_regexes: ["debug-ok"]
+      only_in_paths: ["**"]
+`)
+	require.NoError(t, err)
+
+	paths := map[string]bool{}
+	for _, v := range violations {
+		paths[v.FilePath] = true
+	}
+	assert.Equal(t, map[string]bool{"src/Report.kt": true, "lib/Util.kt": true}, paths)
+}
+
+func TestAnyOf_UnionAndDedupe(t *testing.T) {
+	violations, err := compositeCheck(t, &AnyOfChecker{}, `
+rules:
+  - type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["println\\(\"total\"\\)"]
+  - type: diff_pattern_forbidden
+    config:
+      forbidden_regexes: ["println\\(\"(total|util)\"\\)"]
+`)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 2)
+	assert.Equal(t, "src/Report.kt", violations[0].FilePath)
+	assert.Equal(t, 2, violations[0].Line)
+	assert.Equal(t, "lib/Util.kt", violations[1].FilePath)
+}
+
+func TestNot_ReportsWholeFile(t *testing.T) {
+	violations, err := compositeCheck(t, &NotChecker{}, `
+only_in_paths: ["src/**"]
+rule:
+  type: diff_pattern_forbidden
+  config:
+    forbidden_regexes: ["debug-ok"]
+`)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "src/Report.kt", violations[0].FilePath)
+	assert.Zero(t, violations[0].Line)
+	assert.Equal(t, "no_println", violations[0].RuleID)
+}
+
+func TestComposite_Nested(t *testing.T) {
+	violations, err := compositeCheck(t, &AnyOfChecker{}, `
+rules:
+  - type: all_of
+    config:
+      rules:
+        - type: diff_pattern_forbidden
+          config:
+            forbidden_regexes: ["println"]
+            only_in_paths: ["lib/**"]
+  - type: all_of
+    config:
+      rules:
+        - type: diff_pattern_forbidden
+          config:
+            forbidden_regexes: ["debug-ok"]
+`)
+	require.NoError(t, err)
+
+	paths := []string{}
+	for _, v := range violations {
+		paths = append(paths, v.FilePath)
+	}
+	assert.Equal(t, []string{"src/Debug.kt", "lib/Util.kt"}, paths)
+}
+
+func TestComposite_NestedCommitMessage(t *testing.T) {
+	// Changes to src/ need a Refs trailer on every commit of the range.
+	violations, err := (&AllOfChecker{}).Check(context.Background(), &CheckContext{
+		ChangedFiles: compositeTestFiles,
+		DiffContent:  compositeTestDiff,
+		RuleConfig: compositeConfig(t, `
+only_in_paths: ["src/**"]
+rules:
+  - type: commit_message
+    config:
+      required_trailers: ["Refs: ^[A-Z]+-[0-9]+$"]
+`),
+		Severity: "error",
+		RuleID:   "src_refs",
+		RuleDesc: "Changes to src/ reference an issue",
+		Commits:  testCommits,
+	})
+	require.NoError(t, err)
+
+	// Each commit is reported once, not once per file.
+	commits := []string{}
+	for _, v := range violations {
+		assert.Equal(t, "src_refs", v.RuleID)
+		commits = append(commits, v.Commit[:1])
+	}
+	assert.Equal(t, []string{"2", "3", "4"}, commits)
+}
+
+func TestComposite_ConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker RuleChecker
+		cfg     string
+		want    string
+	}{
+		{"missing rules", &AllOfChecker{}, "{}", `all_of: missing config key "rules"`},
+		{"empty rules", &AnyOfChecker{}, "rules: []", "expected a non-empty list of rules"},
+		{"unknown type", &AllOfChecker{}, "rules:\n  - type: nope\n", `rules[0]: unknown rule type "nope"`},
+		{"missing type", &AllOfChecker{}, "rules:\n  - config: {}\n", "missing rule type"},
+		{"missing rule", &NotChecker{}, "{}", `not: missing config key "rule"`},
+		{
+			"nested error",
+			&AllOfChecker{},
+			"rules:\n  - type: diff_pattern_forbidden\n    config: {}\n",
+			"all_of: rules[0] (diff_pattern_forbidden): diff_pattern_forbidden: missing config key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compositeCheck(t, tt.checker, tt.cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestComposite_Types(t *testing.T) {
+	assert.Equal(t, "all_of", (&AllOfChecker{}).Type())
+	assert.Equal(t, "any_of", (&AnyOfChecker{}).Type())
+	assert.Equal(t, "not", (&NotChecker{}).Type())
+}
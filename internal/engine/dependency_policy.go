@@ -0,0 +1,411 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DependencyPolicyChecker enforces a policy on the dependencies declared in
+// manifests (go.mod, package.json, build.gradle, build.gradle.kts and
+// requirements.txt). It parses each changed manifest at the base and head
+// revisions, works out which dependencies were added, removed or changed
+// version, and reports one violation per dependency that matches the deny
+// list, is missing from the allow list, (with forbid_major_upgrades) moves
+// to a higher major version, or (with forbid_removals) is removed.
+// Optionally scoped to specific file path patterns via only_in_paths.
+type DependencyPolicyChecker struct{}
+
+// dependency is a package declared in a manifest.
+type dependency struct {
+	Name    string
+	Version string // as written in the manifest, e.g., "v1.2.3" or "^4.17.0"
+	Line    int    // line of the declaration, 0 if unknown
+	// key identifies the dependency across versions. It differs from Name
+	// for Go modules, whose major version is part of the module path.
+	key string
+}
+
+// dependencyChange is a dependency added or removed by a diff, or whose
+// version changed.
+type dependencyChange struct {
+	Base *dependency // nil if the dependency was added
+	Head *dependency // nil if the dependency was removed
+	// Snippet is the line that declares the dependency in the head manifest,
+	// prefixed with "+", or for a removal in the base manifest, prefixed
+	// with "-".
+	Snippet string
+}
+
+// manifestParsers maps manifest file names to their parsers.
+var manifestParsers = map[string]func(content string) ([]dependency, error){
+	"go.mod":           parseGoMod,
+	"package.json":     parsePackageJSON,
+	"build.gradle":     parseGradle,
+	"build.gradle.kts": parseGradle,
+	"requirements.txt": parseRequirements,
+}
+
+// Type returns the checker type identifier.
+func (c *DependencyPolicyChecker) Type() string {
+	return "dependency_policy"
+}
+
+// Check evaluates the dependency_policy rule against the given context.
+func (c *DependencyPolicyChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	deny, _ := getStringSlice(cc.RuleConfig, "deny")
+	allow, _ := getStringSlice(cc.RuleConfig, "allow")
+	forbidMajor, err := getBool(cc.RuleConfig, "forbid_major_upgrades")
+	if err != nil {
+		return nil, fmt.Errorf("dependency_policy: %w", err)
+	}
+	forbidRemovals, err := getBool(cc.RuleConfig, "forbid_removals")
+	if err != nil {
+		return nil, fmt.Errorf("dependency_policy: %w", err)
+	}
+	if len(deny) == 0 && len(allow) == 0 && !forbidMajor && !forbidRemovals {
+		return nil, fmt.Errorf("dependency_policy: at least one of deny, allow, forbid_major_upgrades or forbid_removals is required")
+	}
+	onlyInPaths, _ := getStringSlice(cc.RuleConfig, "only_in_paths")
+
+	var violations []Violation
+	for _, fd := range cc.ParsedDiff().Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		parse, ok := manifestParsers[path.Base(fd.Path)]
+		if !ok || fd.IsBinary || (fd.Kind == ChangeDeleted && !forbidRemovals) {
+			continue
+		}
+		if len(onlyInPaths) > 0 && !matchesAnyGlob(fd.Path, onlyInPaths) {
+			continue
+		}
+		if cc.Repo == nil {
+			return nil, fmt.Errorf("dependency_policy: file contents are not available")
+		}
+
+		changes, err := manifestChanges(cc.Repo, fd, parse)
+		if err != nil {
+			return nil, fmt.Errorf("dependency_policy: %w", err)
+		}
+
+		for _, change := range changes {
+			reason := dependencyPolicyReason(change, deny, allow, forbidMajor, forbidRemovals)
+			if reason == "" {
+				continue
+			}
+			// A removed dependency has no line in the head manifest.
+			line := 0
+			if change.Head != nil {
+				line = change.Head.Line
+			}
+			violations = append(violations, Violation{
+				RuleID:      cc.RuleID,
+				Severity:    cc.Severity,
+				Description: fmt.Sprintf("%s (%s)", cc.RuleDesc, reason),
+				FilePath:    fd.Path,
+				Line:        line,
+				EndLine:     line,
+				DiffSnippet: change.Snippet,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// manifestChanges parses a manifest at both revisions and returns the
+// dependencies added or changed by the diff, in head order, followed by
+// those it removed, in base order. A deleted manifest removes all of its
+// dependencies.
+func manifestChanges(repo RepoReader, fd FileDiff, parse func(string) ([]dependency, error)) ([]dependencyChange, error) {
+	var base []dependency
+	var baseLines []string
+	if fd.Kind != ChangeAdded {
+		oldPath := fd.OldPath
+		if oldPath == "" {
+			oldPath = fd.Path
+		}
+		content, err := repo.ReadBase(oldPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", oldPath, err)
+		}
+		if base, err = parse(string(content)); err != nil {
+			return nil, fmt.Errorf("parsing %s at the base revision: %w", oldPath, err)
+		}
+		baseLines = strings.Split(string(content), "\n")
+	}
+
+	var head []dependency
+	var headLines []string
+	if fd.Kind != ChangeDeleted {
+		content, err := repo.ReadHead(fd.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", fd.Path, err)
+		}
+		if head, err = parse(string(content)); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", fd.Path, err)
+		}
+		headLines = strings.Split(string(content), "\n")
+	}
+
+	baseByKey := make(map[string]*dependency, len(base))
+	for i := range base {
+		baseByKey[base[i].key] = &base[i]
+	}
+	headKeys := make(map[string]bool, len(head))
+
+	var changes []dependencyChange
+	for i := range head {
+		dep := &head[i]
+		headKeys[dep.key] = true
+		old, ok := baseByKey[dep.key]
+		switch {
+		case !ok:
+			changes = append(changes, dependencyChange{Head: dep, Snippet: declarationLine("+", headLines, dep.Line)})
+		case old.Name != dep.Name || old.Version != dep.Version:
+			changes = append(changes, dependencyChange{Base: old, Head: dep, Snippet: declarationLine("+", headLines, dep.Line)})
+		}
+	}
+	for i := range base {
+		if dep := &base[i]; !headKeys[dep.key] {
+			changes = append(changes, dependencyChange{Base: dep, Snippet: declarationLine("-", baseLines, dep.Line)})
+		}
+	}
+	return changes, nil
+}
+
+// declarationLine returns line n of a manifest as a diff line with the given
+// marker, or "" if the line is unknown.
+func declarationLine(marker string, lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return marker + lines[n-1]
+}
+
+// dependencyPolicyReason explains why a change violates the policy, or
+// returns "" if it does not.
+func dependencyPolicyReason(change dependencyChange, deny, allow []string, forbidMajor, forbidRemovals bool) string {
+	if change.Head == nil {
+		if forbidRemovals {
+			return fmt.Sprintf("dependency %s is removed", change.Base.Name)
+		}
+		return ""
+	}
+
+	dep := change.Head
+	switch {
+	case matchesAnyDependency(dep.Name, deny):
+		return fmt.Sprintf("dependency %s is denied", dep.Name)
+	case change.Base == nil && len(allow) > 0 && !matchesAnyDependency(dep.Name, allow):
+		return fmt.Sprintf("dependency %s is not in the allow list", dep.Name)
+	case change.Base != nil && forbidMajor && isMajorUpgrade(change.Base.Version, dep.Version):
+		return fmt.Sprintf("major upgrade of %s from %s to %s", dep.Name, change.Base.Version, dep.Version)
+	}
+	return ""
+}
+
+// matchesAnyDependency reports whether a dependency name matches one of the
+// patterns. Patterns are globs in which * matches any run of characters,
+// including "/" and ":", so "org.apache.logging.log4j:*" matches every
+// artifact of the group.
+func matchesAnyDependency(name string, patterns []string) bool {
+	for _, p := range patterns {
+		re, err := compileRegex("^" + strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, ".*") + "$")
+		if err == nil && re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// majorVersionPattern finds the major version in a version or version
+// constraint, e.g., 4 in "^4.17.0", "v4.1.0" or ">=4.0,<5".
+var majorVersionPattern = regexp.MustCompile(`^[^0-9]*?(\d+)`)
+
+// isMajorUpgrade reports whether the major version of head is higher than
+// that of base. Versions without a number (e.g., "latest") are not compared.
+func isMajorUpgrade(base, head string) bool {
+	baseMajor, ok1 := majorVersion(base)
+	headMajor, ok2 := majorVersion(head)
+	return ok1 && ok2 && headMajor > baseMajor
+}
+
+func majorVersion(version string) (int, bool) {
+	m := majorVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+// goModuleMajorSuffix matches the major version suffix of a Go module path,
+// e.g., "/v2" in "github.com/foo/bar/v2".
+var goModuleMajorSuffix = regexp.MustCompile(`/v\d+$`)
+
+// goRequirePattern matches a requirement in go.mod, either on a require line
+// or inside a require block.
+var goRequirePattern = regexp.MustCompile(`^\s*(?:require\s+)?([^\s()]+)\s+(v[^\s]+)`)
+
+// parseGoMod returns the modules required by a go.mod file.
+func parseGoMod(content string) ([]dependency, error) {
+	var deps []dependency
+	inRequire := false
+	for i, line := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(line)
+		if j := strings.Index(text, "//"); j >= 0 {
+			text = strings.TrimSpace(text[:j])
+		}
+		switch {
+		case strings.HasPrefix(text, "require") && strings.HasSuffix(text, "("):
+			inRequire = true
+			continue
+		case inRequire && text == ")":
+			inRequire = false
+			continue
+		case !inRequire && !strings.HasPrefix(text, "require "):
+			continue
+		}
+
+		m := goRequirePattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, dependency{
+			Name:    m[1],
+			Version: m[2],
+			Line:    i + 1,
+			key:     goModuleMajorSuffix.ReplaceAllString(m[1], ""),
+		})
+	}
+	return deps, nil
+}
+
+// packageJSONSections are the package.json fields that declare dependencies.
+var packageJSONSections = []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"}
+
+// parsePackageJSON returns the packages declared in a package.json file. A
+// package listed in several sections is returned once.
+func parsePackageJSON(content string) ([]dependency, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(content, "\n")
+	seen := map[string]bool{}
+	var deps []dependency
+	for _, section := range packageJSONSections {
+		raw, ok := manifest[section]
+		if !ok {
+			continue
+		}
+		var versions map[string]string
+		if err := json.Unmarshal(raw, &versions); err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		for name, version := range versions {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			deps = append(deps, dependency{Name: name, Version: version, Line: jsonKeyLine(lines, name), key: name})
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Line != deps[j].Line {
+			return deps[i].Line < deps[j].Line
+		}
+		return deps[i].Name < deps[j].Name
+	})
+	return deps, nil
+}
+
+// jsonKeyLine returns the first line that declares key as a JSON object key,
+// or 0 if there is none.
+func jsonKeyLine(lines []string, key string) int {
+	quoted, _ := json.Marshal(key)
+	for i, line := range lines {
+		if j := strings.Index(line, string(quoted)); j >= 0 && strings.HasPrefix(strings.TrimSpace(line[j+len(quoted):]), ":") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// gradleDependencyPattern matches a "group:artifact:version" coordinate in
+// a Gradle dependency declaration, in Groovy or Kotlin syntax, e.g.,
+// implementation("com.squareup.okhttp3:okhttp:4.12.0").
+var gradleDependencyPattern = regexp.MustCompile(`^\s*\w+\s*\(?\s*["']([\w.\-]+:[\w.\-]+)(?::([^"'@\s]+))?(?:@\w+)?["']`)
+
+// parseGradle returns the dependencies declared with string coordinates in
+// a build.gradle or build.gradle.kts file.
+func parseGradle(content string) ([]dependency, error) {
+	var deps []dependency
+	for i, line := range strings.Split(content, "\n") {
+		m := gradleDependencyPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, dependency{Name: m[1], Version: m[2], Line: i + 1, key: m[1]})
+	}
+	return deps, nil
+}
+
+// requirementPattern matches a requirement line: a project name, optional
+// extras and an optional version specifier.
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._\-]*)\s*(?:\[[^\]]*\])?\s*([<>=!~][^;#]*)?`)
+
+// pep503Separators matches the runs of separators that PEP 503 name
+// normalization replaces with a single "-".
+var pep503Separators = regexp.MustCompile(`[-_.]+`)
+
+// parseRequirements returns the projects listed in a requirements.txt file.
+// Names are normalized as in PEP 503, so "Django" and "django" are the same
+// project. Options such as -r and -e are skipped.
+func parseRequirements(content string) ([]dependency, error) {
+	var deps []dependency
+	for i, line := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(line)
+		if j := strings.Index(text, "#"); j >= 0 {
+			text = strings.TrimSpace(text[:j])
+		}
+		if text == "" || strings.HasPrefix(text, "-") {
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		name := strings.ToLower(pep503Separators.ReplaceAllString(m[1], "-"))
+		deps = append(deps, dependency{Name: name, Version: strings.TrimSpace(m[2]), Line: i + 1, key: name})
+	}
+	return deps, nil
+}
+
+// getBool extracts a bool from a map[string]interface{} by key. A missing
+// key is false.
+func getBool(cfg map[string]interface{}, key string) (bool, error) {
+	val, ok := cfg[key]
+	if !ok {
+		return false, nil
+	}
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("config key %q: expected a boolean, got %T", key, val)
+	}
+	return b, nil
+}
@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manifestDiff renders a diff that modifies each of the given files. The
+// hunks are irrelevant to dependency_policy, which reads whole files.
+func manifestDiff(paths ...string) string {
+	diff := ""
+	for _, p := range paths {
+		diff += "diff --git a/" + p + " b/" + p + "\n--- a/" + p + "\n+++ b/" + p + "\n@@ -1 +1 @@\n-x\n+y\n"
+	}
+	return diff
+}
+
+func checkDependencies(t *testing.T, cfg map[string]interface{}, diff string, repo RepoReader) ([]Violation, error) {
+	t.Helper()
+	parsed := NewParsedDiff(diff)
+	var files []string
+	for _, fd := range parsed.Files {
+		files = append(files, fd.Path)
+	}
+	return (&DependencyPolicyChecker{}).Check(context.Background(), &CheckContext{
+		ChangedFiles: files,
+		DiffContent:  diff,
+		RuleConfig:   cfg,
+		Severity:     "error",
+		RuleID:       "deps",
+		RuleDesc:     "Dependencies need approval",
+		Repo:         repo,
+	})
+}
+
+func descriptions(violations []Violation) []string {
+	result := make([]string, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, v.Description)
+	}
+	return result
+}
+
+func TestDependencyPolicy_GoMod(t *testing.T) {
+	repo := &mapRepo{
+		base: map[string]string{"go.mod": `module example.com/app
+
+go 1.21
+
+require github.com/stretchr/testify v1.8.0
+
+require (
+	github.com/spf13/cobra v1.7.0
+	golang.org/x/text v0.3.0 // indirect
+)
+`},
+		head: map[string]string{"go.mod": `module example.com/app
+
+go 1.21
+
+require github.com/stretchr/testify v1.9.0
+
+require (
+	github.com/spf13/cobra/v2 v2.0.0
+	golang.org/x/text v0.14.0 // indirect
+	github.com/pkg/errors v0.9.1
+	github.com/acme/tools v1.0.0
+)
+`},
+	}
+
+	violations, err := checkDependencies(t, map[string]interface{}{
+		"deny":                  []interface{}{"github.com/pkg/errors"},
+		"allow":                 []interface{}{"github.com/stretchr/*", "golang.org/x/*"},
+		"forbid_major_upgrades": true,
+	}, manifestDiff("go.mod"), repo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Dependencies need approval (major upgrade of github.com/spf13/cobra/v2 from v1.7.0 to v2.0.0)",
+		"Dependencies need approval (dependency github.com/pkg/errors is denied)",
+		"Dependencies need approval (dependency github.com/acme/tools is not in the allow list)",
+	}, descriptions(violations), "minor upgrades and existing dependencies pass")
+	assert.Equal(t, 8, violations[0].Line)
+	assert.Equal(t, "+\tgithub.com/spf13/cobra/v2 v2.0.0", violations[0].DiffSnippet)
+	assert.Equal(t, "go.mod", violations[0].FilePath)
+	assert.Equal(t, 10, violations[1].Line)
+}
+
+func TestDependencyPolicy_PackageJSON(t *testing.T) {
+	repo := &mapRepo{
+		base: map[string]string{"web/package.json": `{
+  "name": "web",
+  "dependencies": {
+    "react": "^17.0.2"
+  }
+}
+`},
+		head: map[string]string{"web/package.json": `{
+  "name": "web",
+  "dependencies": {
+    "react": "^18.2.0",
+    "left-pad": "1.3.0"
+  },
+  "devDependencies": {
+    "jest": "~29.0.0"
+  }
+}
+`},
+	}
+
+	violations, err := checkDependencies(t, map[string]interface{}{
+		"deny":                  []interface{}{"left-pad"},
+		"forbid_major_upgrades": true,
+	}, manifestDiff("web/package.json"), repo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Dependencies need approval (major upgrade of react from ^17.0.2 to ^18.2.0)",
+		"Dependencies need approval (dependency left-pad is denied)",
+	}, descriptions(violations))
+	assert.Equal(t, 4, violations[0].Line)
+	assert.Equal(t, 5, violations[1].Line)
+}
+
+func TestDependencyPolicy_GradleAndRequirements(t *testing.T) {
+	repo := &mapRepo{
+		base: map[string]string{
+			"app/build.gradle.kts": "dependencies {\n    implementation(\"com.squareup.okhttp3:okhttp:3.14.9\")\n}\n",
+			"requirements.txt":     "Django==3.2\nrequests>=2.0  # http\n",
+		},
+		head: map[string]string{
+			"app/build.gradle.kts": "dependencies {\n    implementation(\"com.squareup.okhttp3:okhttp:4.12.0\")\n    testImplementation 'org.apache.logging.log4j:log4j-core:2.14.0'\n}\n",
+			"requirements.txt":     "-r base.txt\ndjango==4.2\nrequests>=2.31\nPyYAML[extra]==6.0\n",
+		},
+	}
+
+	violations, err := checkDependencies(t, map[string]interface{}{
+		"deny":                  []interface{}{"org.apache.logging.log4j:*", "pyyaml"},
+		"forbid_major_upgrades": true,
+	}, manifestDiff("app/build.gradle.kts", "requirements.txt"), repo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Dependencies need approval (major upgrade of com.squareup.okhttp3:okhttp from 3.14.9 to 4.12.0)",
+		"Dependencies need approval (dependency org.apache.logging.log4j:log4j-core is denied)",
+		"Dependencies need approval (major upgrade of django from ==3.2 to ==4.2)",
+		"Dependencies need approval (dependency pyyaml is denied)",
+	}, descriptions(violations))
+	assert.Equal(t, "requirements.txt", violations[2].FilePath)
+	assert.Equal(t, 2, violations[2].Line)
+}
+
+func TestDependencyPolicy_AddedAndDeletedManifests(t *testing.T) {
+	diff := "diff --git a/requirements.txt b/requirements.txt\nnew file mode 100644\n--- /dev/null\n+++ b/requirements.txt\n@@ -0,0 +1 @@\n+flask==3.0\n" +
+		"diff --git a/go.mod b/go.mod\ndeleted file mode 100644\n--- a/go.mod\n+++ /dev/null\n@@ -1 +0,0 @@\n-module x\n"
+	repo := &mapRepo{head: map[string]string{"requirements.txt": "flask==3.0\n"}}
+
+	violations, err := checkDependencies(t, map[string]interface{}{"allow": []interface{}{"django"}}, diff, repo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dependencies need approval (dependency flask is not in the allow list)"}, descriptions(violations))
+}
+
+func TestDependencyPolicy_Removals(t *testing.T) {
+	diff := manifestDiff("go.mod") +
+		"diff --git a/web/package.json b/web/package.json\ndeleted file mode 100644\n--- a/web/package.json\n+++ /dev/null\n@@ -1 +0,0 @@\n-{}\n"
+	repo := &mapRepo{
+		base: map[string]string{
+			"go.mod":           "module example.com/app\n\nrequire (\n\tgithub.com/acme/audit v1.2.0\n\tgithub.com/spf13/cobra v1.7.0\n)\n",
+			"web/package.json": `{"dependencies": {"react": "^18.2.0"}}`,
+		},
+		head: map[string]string{
+			"go.mod": "module example.com/app\n\nrequire (\n\tgithub.com/spf13/cobra/v2 v2.0.0\n)\n",
+		},
+	}
+
+	// Without forbid_removals, removals and deleted manifests pass.
+	violations, err := checkDependencies(t, map[string]interface{}{"deny": []interface{}{"left-pad"}}, diff, repo)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = checkDependencies(t, map[string]interface{}{"forbid_removals": true}, diff, repo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Dependencies need approval (dependency github.com/acme/audit is removed)",
+		"Dependencies need approval (dependency react is removed)",
+	}, descriptions(violations), "a major upgrade is not a removal")
+	assert.Equal(t, "go.mod", violations[0].FilePath)
+	assert.Zero(t, violations[0].Line)
+	assert.Equal(t, "-\tgithub.com/acme/audit v1.2.0", violations[0].DiffSnippet)
+	assert.Equal(t, "web/package.json", violations[1].FilePath)
+}
+
+func TestDependencyPolicy_Errors(t *testing.T) {
+	repo := &mapRepo{
+		base: map[string]string{"package.json": "{}"},
+		head: map[string]string{"package.json": "{not json"},
+	}
+	diff := manifestDiff("package.json")
+
+	tests := []struct {
+		name string
+		cfg  map[string]interface{}
+		repo RepoReader
+		want string
+	}{
+		{"no policy", map[string]interface{}{}, repo, "at least one of deny, allow, forbid_major_upgrades or forbid_removals is required"},
+		{"bad bool", map[string]interface{}{"forbid_major_upgrades": "yes"}, repo, `config key "forbid_major_upgrades": expected a boolean`},
+		{"bad removals bool", map[string]interface{}{"forbid_removals": 1}, repo, `config key "forbid_removals": expected a boolean`},
+		{"no repo", map[string]interface{}{"deny": []interface{}{"x"}}, nil, "file contents are not available"},
+		{"invalid manifest", map[string]interface{}{"deny": []interface{}{"x"}}, repo, "parsing package.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkDependencies(t, tt.cfg, diff, tt.repo)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "dependency_policy: "+tt.want)
+		})
+	}
+}
+
+func TestDependencyPolicy_IgnoresOtherFiles(t *testing.T) {
+	violations, err := checkDependencies(t, map[string]interface{}{
+		"deny":          []interface{}{"*"},
+		"only_in_paths": []interface{}{"services/**"},
+	}, manifestDiff("README.md", "go.mod"), &mapRepo{})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestIsMajorUpgrade(t *testing.T) {
+	assert.True(t, isMajorUpgrade("v1.9.0", "v2.0.0"))
+	assert.True(t, isMajorUpgrade("^0.9.1", "^1.0.0"))
+	assert.True(t, isMajorUpgrade(">=1.0,<2", ">=2.0"))
+	assert.False(t, isMajorUpgrade("1.2.3", "1.9.0"))
+	assert.False(t, isMajorUpgrade("2.0.0", "1.0.0"))
+	assert.False(t, isMajorUpgrade("latest", "2.0.0"))
+}
@@ -1,18 +1,66 @@
 package engine
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
+// ChangeKind describes how a file was changed by a diff.
+type ChangeKind string
+
+// Change kinds reported by ParseDiff.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeModified ChangeKind = "modified"
+	ChangeDeleted  ChangeKind = "deleted"
+	ChangeRenamed  ChangeKind = "renamed"
+	ChangeCopied   ChangeKind = "copied"
+)
+
+// changeKinds lists all valid change kinds.
+var changeKinds = []ChangeKind{ChangeAdded, ChangeModified, ChangeDeleted, ChangeRenamed, ChangeCopied}
+
 // FileDiff represents the diff for a single file.
 type FileDiff struct {
-	Path       string
-	AddedLines []string // lines starting with "+" (without the leading "+")
+	// Path is the file's path after the change, or its old path if the file
+	// was deleted. It matches the entry in the list of changed files.
+	Path     string
+	OldPath  string     // path before the change; empty for added files
+	NewPath  string     // path after the change; empty for deleted files
+	Kind     ChangeKind // added, modified, deleted, renamed or copied
+	IsBinary bool       // git reported a binary patch; no lines are collected
+	OldMode  string     // file mode before the change, if reported (e.g., "100644")
+	NewMode  string     // file mode after the change, if reported (e.g., "100755")
+
+	AddedLines   []string // lines starting with "+" (without the leading "+")
+	AddedLineNos []int    // new-file line number of each entry in AddedLines
+	RemovedLines []string // lines starting with "-" (without the leading "-")
+	// RemovedLineNos holds, for each entry in RemovedLines, the new-file line
+	// number at which the line was deleted, so that removals can be reported
+	// against the head revision like additions.
+	RemovedLineNos []int
+}
+
+// ModeChanged reports whether the diff changes the mode of an existing file.
+func (fd FileDiff) ModeChanged() bool {
+	return fd.OldMode != "" && fd.NewMode != "" && fd.OldMode != fd.NewMode
+}
+
+// diffLine is a single changed line of a file diff.
+type diffLine struct {
+	Text string // line content including its leading "+" or "-" marker
+	Line int    // new-file line number
 }
 
 // ParseDiff parses unified diff content into per-file diffs. Each entry in the
-// returned slice corresponds to one file in the diff and contains the file path
-// and all added lines (lines prefixed with "+", excluding the "+++ b/" header).
+// returned slice corresponds to one file in the diff and contains the old and
+// new paths, the kind of change, all added lines (lines prefixed with "+",
+// excluding the "+++ b/" header) and all removed lines (lines prefixed with
+// "-", excluding the "--- a/" header). Line numbers are tracked from the
+// "@@ -a,b +c,d @@" hunk headers. Renames and copies are recognized from the
+// extended headers produced by "git diff -M"; binary patches are flagged but
+// carry no lines.
 func ParseDiff(diffContent string) []FileDiff {
 	if diffContent == "" {
 		return nil
@@ -21,45 +69,253 @@ func ParseDiff(diffContent string) []FileDiff {
 	var result []FileDiff
 	var current *FileDiff
 
+	// inHunk is true once the file headers have been read. Inside a hunk,
+	// "+++" and "---" prefixes are content lines, not headers.
+	inHunk := false
+	newLine := 0
+
+	flush := func() {
+		if current != nil {
+			result = append(result, finishFileDiff(*current))
+		}
+	}
+
 	lines := strings.Split(diffContent, "\n")
 	for _, line := range lines {
 		// Detect new file in diff.
 		if strings.HasPrefix(line, "diff --git ") {
-			if current != nil {
-				result = append(result, *current)
-			}
-			current = &FileDiff{}
+			flush()
+			current = &FileDiff{Kind: ChangeModified}
+			current.OldPath, current.NewPath = parseDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+			inHunk = false
 			continue
 		}
 
-		// Extract file path from the "+++ b/..." line.
-		if strings.HasPrefix(line, "+++ b/") {
-			if current != nil {
-				current.Path = strings.TrimPrefix(line, "+++ b/")
-			}
+		if current == nil {
 			continue
 		}
 
-		// Skip the "--- a/..." header line.
-		if strings.HasPrefix(line, "--- ") {
+		// Hunk headers start (or continue) the content section.
+		if strings.HasPrefix(line, "@@") {
+			inHunk = true
+			newLine = parseHunkNewStart(line)
 			continue
 		}
 
-		// Skip hunk headers.
-		if strings.HasPrefix(line, "@@") {
+		if !inHunk {
+			parseExtendedHeader(current, line)
+			if strings.HasPrefix(line, "+++ ") {
+				inHunk = true
+			}
 			continue
 		}
 
-		// Collect added lines (but not the "+++ b/" header which we already handled).
-		if strings.HasPrefix(line, "+") && current != nil {
-			// Store the line without the leading "+"
+		// Collect added and removed lines without their leading marker.
+		switch {
+		case strings.HasPrefix(line, "+"):
 			current.AddedLines = append(current.AddedLines, line[1:])
+			current.AddedLineNos = append(current.AddedLineNos, newLine)
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			current.RemovedLines = append(current.RemovedLines, line[1:])
+			current.RemovedLineNos = append(current.RemovedLineNos, newLine)
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" is not a content line.
+		default:
+			newLine++
 		}
 	}
 
-	if current != nil {
-		result = append(result, *current)
-	}
+	flush()
 
 	return result
 }
+
+// parseExtendedHeader updates fd from a single header line between
+// "diff --git" and the first hunk.
+func parseExtendedHeader(fd *FileDiff, line string) {
+	switch {
+	case strings.HasPrefix(line, "new file mode "):
+		fd.Kind = ChangeAdded
+		fd.NewMode = strings.TrimPrefix(line, "new file mode ")
+	case strings.HasPrefix(line, "deleted file mode "):
+		fd.Kind = ChangeDeleted
+		fd.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+	case strings.HasPrefix(line, "old mode "):
+		fd.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		fd.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "index "):
+		// "index abc..def 100644" carries the mode of an unchanged-mode file.
+		if fields := strings.Fields(line); len(fields) == 3 && fd.OldMode == "" && fd.NewMode == "" {
+			fd.OldMode, fd.NewMode = fields[2], fields[2]
+		}
+	case strings.HasPrefix(line, "rename from "):
+		fd.Kind = ChangeRenamed
+		fd.OldPath = strings.TrimPrefix(line, "rename from ")
+	case strings.HasPrefix(line, "rename to "):
+		fd.Kind = ChangeRenamed
+		fd.NewPath = strings.TrimPrefix(line, "rename to ")
+	case strings.HasPrefix(line, "copy from "):
+		fd.Kind = ChangeCopied
+		fd.OldPath = strings.TrimPrefix(line, "copy from ")
+	case strings.HasPrefix(line, "copy to "):
+		fd.Kind = ChangeCopied
+		fd.NewPath = strings.TrimPrefix(line, "copy to ")
+	case line == "GIT binary patch", strings.HasPrefix(line, "Binary files "):
+		fd.IsBinary = true
+	case line == "--- /dev/null":
+		fd.Kind = ChangeAdded
+		fd.OldPath = ""
+	case strings.HasPrefix(line, "--- a/"):
+		fd.OldPath = strings.TrimPrefix(line, "--- a/")
+	case line == "+++ /dev/null":
+		fd.Kind = ChangeDeleted
+		fd.NewPath = ""
+	case strings.HasPrefix(line, "+++ b/"):
+		// Extract file path from the "+++ b/..." line.
+		fd.NewPath = strings.TrimPrefix(line, "+++ b/")
+	}
+}
+
+// finishFileDiff clears the paths that do not exist on one side of an added
+// or deleted file and sets Path.
+func finishFileDiff(fd FileDiff) FileDiff {
+	switch fd.Kind {
+	case ChangeAdded:
+		fd.OldPath = ""
+	case ChangeDeleted:
+		fd.NewPath = ""
+	}
+
+	fd.Path = fd.NewPath
+	if fd.Path == "" {
+		fd.Path = fd.OldPath
+	}
+	return fd
+}
+
+// parseDiffGitPaths extracts the old and new paths from the "a/<old> b/<new>"
+// part of a "diff --git" line. The line is ambiguous when paths contain
+// " b/", so equal paths are preferred; headers that follow (e.g., "rename
+// from", "+++ b/") override the result.
+func parseDiffGitPaths(s string) (string, string) {
+	if !strings.HasPrefix(s, "a/") {
+		return "", ""
+	}
+
+	// For an unchanged path the line is "a/<p> b/<p>", which splits evenly.
+	if len(s) >= 7 && (len(s)-1)%2 == 0 {
+		mid := (len(s) - 1) / 2
+		if s[mid:mid+3] == " b/" && s[2:mid] == s[mid+3:] {
+			return s[2:mid], s[mid+3:]
+		}
+	}
+
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return s[2:i], s[i+3:]
+	}
+	return "", ""
+}
+
+// parseHunkNewStart returns the new-file start line from a hunk header such
+// as "@@ -10,3 +12,4 @@ func main() {". It returns 0 if the header is malformed.
+func parseHunkNewStart(header string) int {
+	fields := strings.Fields(header)
+	for _, f := range fields[1:] {
+		if !strings.HasPrefix(f, "+") {
+			continue
+		}
+		start := strings.TrimPrefix(f, "+")
+		if i := strings.Index(start, ","); i >= 0 {
+			start = start[:i]
+		}
+		n, err := strconv.Atoi(start)
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
+}
+
+// lineAt returns the entry at index i of a line number slice, or 0 if the
+// slice is shorter (e.g., for a FileDiff built by hand).
+func lineAt(nos []int, i int) int {
+	if i < len(nos) {
+		return nos[i]
+	}
+	return 0
+}
+
+// diffLines returns the changed lines of a file selected by matchOn ("added",
+// "removed" or "both"), each keeping its leading "+" or "-" marker.
+func diffLines(fd FileDiff, matchOn string) []diffLine {
+	var lines []diffLine
+	if matchOn == "added" || matchOn == "both" {
+		for i, l := range fd.AddedLines {
+			lines = append(lines, diffLine{Text: "+" + l, Line: lineAt(fd.AddedLineNos, i)})
+		}
+	}
+	if matchOn == "removed" || matchOn == "both" {
+		for i, l := range fd.RemovedLines {
+			lines = append(lines, diffLine{Text: "-" + l, Line: lineAt(fd.RemovedLineNos, i)})
+		}
+	}
+	return lines
+}
+
+// getMatchOn reads the optional match_on config key, which selects the diff
+// lines a pattern rule applies to. It defaults to "added".
+func getMatchOn(cfg map[string]interface{}) (string, error) {
+	val, ok := cfg["match_on"]
+	if !ok {
+		return "added", nil
+	}
+
+	s, ok := val.(string)
+	if !ok || (s != "added" && s != "removed" && s != "both") {
+		return "", fmt.Errorf("config key \"match_on\": must be one of added, removed, both; got %v", val)
+	}
+	return s, nil
+}
+
+// parseChangeKind validates a change kind name from a rule config.
+func parseChangeKind(s string) (ChangeKind, error) {
+	for _, k := range changeKinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown change kind %q (want one of added, modified, deleted, renamed, copied)", s)
+}
+
+// getChangeKinds reads the optional change_kinds config key, which restricts a
+// rule to files changed in one of the listed ways. It returns nil (meaning
+// every kind) when the key is absent.
+func getChangeKinds(cfg map[string]interface{}) (map[ChangeKind]bool, error) {
+	if _, ok := cfg["change_kinds"]; !ok {
+		return nil, nil
+	}
+
+	names, err := getStringSlice(cfg, "change_kinds")
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[ChangeKind]bool, len(names))
+	for _, name := range names {
+		k, err := parseChangeKind(name)
+		if err != nil {
+			return nil, fmt.Errorf("config key \"change_kinds\": %w", err)
+		}
+		kinds[k] = true
+	}
+	return kinds, nil
+}
+
+// kindAllowed reports whether a file's change kind passes a change_kinds
+// filter. A nil filter allows every kind.
+func kindAllowed(kinds map[ChangeKind]bool, k ChangeKind) bool {
+	return kinds == nil || kinds[k]
+}
@@ -92,6 +92,7 @@ func TestParseDiff_NewFile(t *testing.T) {
 
 	assert.Len(t, result, 1)
 	assert.Equal(t, "newfile.go", result[0].Path)
+	assert.Equal(t, ChangeAdded, result[0].Kind)
 	assert.Len(t, result[0].AddedLines, 3)
 	assert.Equal(t, "package newpkg", result[0].AddedLines[0])
 }
@@ -135,3 +136,207 @@ func TestParseDiff_NoAddedLines(t *testing.T) {
 	assert.Equal(t, "main.go", result[0].Path)
 	assert.Empty(t, result[0].AddedLines)
 }
+
+func TestParseDiff_RemovedLines(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,5 +1,5 @@
+ package main
+
+-import "old"
++import "new"
+
+-// Deprecated: use New.`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, []string{`import "old"`, "// Deprecated: use New."}, result[0].RemovedLines)
+	assert.Equal(t, []string{`import "new"`}, result[0].AddedLines)
+}
+
+func TestParseDiff_HeaderLikeContentLines(t *testing.T) {
+	diff := `diff --git a/schema.sql b/schema.sql
+--- a/schema.sql
++++ b/schema.sql
+@@ -1,2 +1,2 @@
+--- old comment
++++ new comment
+ CREATE TABLE users (id INT);`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "schema.sql", result[0].Path)
+	assert.Equal(t, []string{"-- old comment"}, result[0].RemovedLines)
+	assert.Equal(t, []string{"++ new comment"}, result[0].AddedLines)
+}
+
+func TestParseDiff_LineNumbers(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ package main
+-import "old"
++import "new"
++import "fmt"
+ 
+ func main() {
+@@ -20,3 +21,4 @@ func helper() {
+ 	a()
++	b()
+ }
+\ No newline at end of file`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, []int{2, 3, 22}, result[0].AddedLineNos)
+	assert.Equal(t, []int{2}, result[0].RemovedLineNos)
+}
+
+func TestParseHunkNewStart(t *testing.T) {
+	assert.Equal(t, 12, parseHunkNewStart("@@ -10,3 +12,4 @@ func main() {"))
+	assert.Equal(t, 1, parseHunkNewStart("@@ -0,0 +1 @@"))
+	assert.Equal(t, 0, parseHunkNewStart("@@ malformed @@"))
+}
+
+func TestParseDiff_DeletedFile(t *testing.T) {
+	diff := `diff --git a/migrations/001_init.sql b/migrations/001_init.sql
+deleted file mode 100644
+index e69de29..0000000
+--- a/migrations/001_init.sql
++++ /dev/null
+@@ -1,2 +0,0 @@
+-CREATE TABLE users (id INT);
+-CREATE TABLE orders (id INT);`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, ChangeDeleted, result[0].Kind)
+	assert.Equal(t, "migrations/001_init.sql", result[0].Path)
+	assert.Equal(t, "migrations/001_init.sql", result[0].OldPath)
+	assert.Empty(t, result[0].NewPath)
+	assert.Equal(t, "100644", result[0].OldMode)
+	assert.Len(t, result[0].RemovedLines, 2)
+}
+
+func TestParseDiff_RenamedFile(t *testing.T) {
+	diff := `diff --git a/old/name.go b/new/name.go
+similarity index 90%
+rename from old/name.go
+rename to new/name.go
+index 1111111..2222222 100644
+--- a/old/name.go
++++ b/new/name.go
+@@ -1,2 +1,2 @@
+-package old
++package new
+diff --git a/docs/a.md b/docs/b.md
+similarity index 100%
+rename from docs/a.md
+rename to docs/b.md`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 2)
+
+	assert.Equal(t, ChangeRenamed, result[0].Kind)
+	assert.Equal(t, "old/name.go", result[0].OldPath)
+	assert.Equal(t, "new/name.go", result[0].NewPath)
+	assert.Equal(t, "new/name.go", result[0].Path)
+	assert.Equal(t, []string{"package new"}, result[0].AddedLines)
+
+	// A pure rename has no "---"/"+++" headers and no hunks.
+	assert.Equal(t, ChangeRenamed, result[1].Kind)
+	assert.Equal(t, "docs/a.md", result[1].OldPath)
+	assert.Equal(t, "docs/b.md", result[1].Path)
+	assert.Empty(t, result[1].AddedLines)
+}
+
+func TestParseDiff_CopiedFile(t *testing.T) {
+	diff := `diff --git a/tmpl/base.yml b/tmpl/prod.yml
+similarity index 100%
+copy from tmpl/base.yml
+copy to tmpl/prod.yml`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, ChangeCopied, result[0].Kind)
+	assert.Equal(t, "tmpl/base.yml", result[0].OldPath)
+	assert.Equal(t, "tmpl/prod.yml", result[0].Path)
+}
+
+func TestParseDiff_BinaryFile(t *testing.T) {
+	diff := `diff --git a/assets/logo.png b/assets/logo.png
+new file mode 100644
+index 0000000..3f4e5d6
+Binary files /dev/null and b/assets/logo.png differ
+diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1,2 @@
+ package main
++import "fmt"`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "assets/logo.png", result[0].Path)
+	assert.Equal(t, ChangeAdded, result[0].Kind)
+	assert.True(t, result[0].IsBinary)
+	assert.Empty(t, result[0].AddedLines)
+
+	assert.Equal(t, "main.go", result[1].Path)
+	assert.False(t, result[1].IsBinary)
+	assert.Equal(t, ChangeModified, result[1].Kind)
+}
+
+func TestParseDiff_ModeChange(t *testing.T) {
+	diff := `diff --git a/scripts/run.sh b/scripts/run.sh
+old mode 100644
+new mode 100755`
+
+	result := ParseDiff(diff)
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "scripts/run.sh", result[0].Path)
+	assert.Equal(t, ChangeModified, result[0].Kind)
+	assert.Equal(t, "100644", result[0].OldMode)
+	assert.Equal(t, "100755", result[0].NewMode)
+	assert.True(t, result[0].ModeChanged())
+}
+
+func TestParseDiffGitPaths(t *testing.T) {
+	oldPath, newPath := parseDiffGitPaths("a/dir b/x.go b/dir b/x.go")
+	assert.Equal(t, "dir b/x.go", oldPath)
+	assert.Equal(t, "dir b/x.go", newPath)
+
+	oldPath, newPath = parseDiffGitPaths("a/one.go b/two.go")
+	assert.Equal(t, "one.go", oldPath)
+	assert.Equal(t, "two.go", newPath)
+}
+
+func TestGetChangeKinds(t *testing.T) {
+	kinds, err := getChangeKinds(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Nil(t, kinds)
+	assert.True(t, kindAllowed(kinds, ChangeModified))
+
+	kinds, err = getChangeKinds(map[string]interface{}{
+		"change_kinds": []interface{}{"deleted", "renamed"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, kindAllowed(kinds, ChangeDeleted))
+	assert.False(t, kindAllowed(kinds, ChangeModified))
+
+	_, err = getChangeKinds(map[string]interface{}{
+		"change_kinds": []interface{}{"moved"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "moved")
+}
@@ -1,13 +1,17 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 )
 
 // DiffPatternForbiddenChecker checks that added lines in the diff do not match
 // any of the configured forbidden regular expressions. Optionally scoped to
-// specific file path patterns via only_in_paths.
+// specific file path patterns via only_in_paths. With match_on set to
+// "removed" or "both", removed lines are checked as well, which forbids
+// deleting certain code (e.g., a @Deprecated marker). The optional
+// change_kinds list restricts the rule to files changed in those ways.
 type DiffPatternForbiddenChecker struct{}
 
 // Type returns the checker type identifier.
@@ -16,8 +20,18 @@ func (c *DiffPatternForbiddenChecker) Type() string {
 }
 
 // Check evaluates the diff_pattern_forbidden rule against the given context.
-func (c *DiffPatternForbiddenChecker) Check(ctx *CheckContext) ([]Violation, error) {
-	forbiddenRegexes, err := getStringSlice(ctx.RuleConfig, "forbidden_regexes")
+func (c *DiffPatternForbiddenChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	forbiddenRegexes, err := getStringSlice(cc.RuleConfig, "forbidden_regexes")
+	if err != nil {
+		return nil, fmt.Errorf("diff_pattern_forbidden: %w", err)
+	}
+
+	matchOn, err := getMatchOn(cc.RuleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("diff_pattern_forbidden: %w", err)
+	}
+
+	kinds, err := getChangeKinds(cc.RuleConfig)
 	if err != nil {
 		return nil, fmt.Errorf("diff_pattern_forbidden: %w", err)
 	}
@@ -25,7 +39,7 @@ func (c *DiffPatternForbiddenChecker) Check(ctx *CheckContext) ([]Violation, err
 	// Compile all forbidden regexes.
 	compiled := make([]*regexp.Regexp, 0, len(forbiddenRegexes))
 	for _, pattern := range forbiddenRegexes {
-		re, err := regexp.Compile(pattern)
+		re, err := compileRegex(pattern)
 		if err != nil {
 			return nil, fmt.Errorf("diff_pattern_forbidden: invalid regex %q: %w", pattern, err)
 		}
@@ -33,10 +47,10 @@ func (c *DiffPatternForbiddenChecker) Check(ctx *CheckContext) ([]Violation, err
 	}
 
 	// Determine which files to check.
-	filesToCheck := ctx.ChangedFiles
-	onlyInPaths, _ := getStringSlice(ctx.RuleConfig, "only_in_paths")
+	filesToCheck := cc.ChangedFiles
+	onlyInPaths, _ := getStringSlice(cc.RuleConfig, "only_in_paths")
 	if len(onlyInPaths) > 0 {
-		filesToCheck = filterFilesByGlobs(ctx.ChangedFiles, onlyInPaths)
+		filesToCheck = filterFilesByGlobs(cc.ChangedFiles, onlyInPaths)
 		if len(filesToCheck) == 0 {
 			return nil, nil
 		}
@@ -48,24 +62,28 @@ func (c *DiffPatternForbiddenChecker) Check(ctx *CheckContext) ([]Violation, err
 		fileSet[f] = true
 	}
 
-	// Parse diff and check added lines.
-	fileDiffs := ParseDiff(ctx.DiffContent)
-
+	// Parse diff and check the selected lines.
 	var violations []Violation
-	for _, fd := range fileDiffs {
-		if !fileSet[fd.Path] {
+	for _, fd := range cc.ParsedDiff().Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !fileSet[fd.Path] || !kindAllowed(kinds, fd.Kind) {
 			continue
 		}
 
-		for _, addedLine := range fd.AddedLines {
+		for _, line := range diffLines(fd, matchOn) {
 			for _, re := range compiled {
-				if re.MatchString(addedLine) {
+				if re.MatchString(line.Text[1:]) {
 					violations = append(violations, Violation{
-						RuleID:      ctx.RuleID,
-						Severity:    ctx.Severity,
-						Description: ctx.RuleDesc,
+						RuleID:      cc.RuleID,
+						Severity:    cc.Severity,
+						Description: cc.RuleDesc,
 						FilePath:    fd.Path,
-						DiffSnippet: "+" + addedLine,
+						Line:        line.Line,
+						EndLine:     line.Line,
+						DiffSnippet: line.Text,
 					})
 					break // one violation per line is enough
 				}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestDiffPatternForbidden_BasicViolation(t *testing.T) {
 		RuleDesc: "Money must use int minor units, not float/double",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 1)
@@ -60,7 +61,7 @@ func TestDiffPatternForbidden_NoViolation_FileNotInPaths(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
@@ -88,7 +89,7 @@ func TestDiffPatternForbidden_NoViolation_NoMatchingRegex(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
@@ -113,7 +114,7 @@ func TestDiffPatternForbidden_WithoutOnlyInPaths(t *testing.T) {
 		RuleDesc: "No hardcoded passwords",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 1)
@@ -144,7 +145,7 @@ func TestDiffPatternForbidden_MultipleViolationsInOneFile(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 2)
@@ -163,7 +164,7 @@ func TestDiffPatternForbidden_InvalidRegex(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid regex")
 }
@@ -184,7 +185,7 @@ func TestDiffPatternForbidden_MissingConfig(t *testing.T) {
 		RuleDesc:     "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "forbidden_regexes")
 }
@@ -216,10 +217,135 @@ diff --git a/docs/readme.md b/docs/readme.md
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	// Only the .kt file should have a violation, not the .md file.
 	assert.Len(t, violations, 1)
 	assert.Equal(t, "src/model.kt", violations[0].FilePath)
 }
+
+func TestDiffPatternForbidden_MatchOnRemoved(t *testing.T) {
+	diff := `diff --git a/src/Api.kt b/src/Api.kt
+--- a/src/Api.kt
++++ b/src/Api.kt
+@@ -1,4 +1,3 @@
+ class Api {
+-    @Deprecated("use v2")
+     fun v1() {}
++    @Deprecated("use v3")
+ }`
+
+	checker := &DiffPatternForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"src/Api.kt"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`@Deprecated`},
+			"match_on":          "removed",
+		},
+		Severity: "error",
+		RuleID:   "keep_deprecations",
+		RuleDesc: "Do not remove @Deprecated markers without a proposal",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, `-    @Deprecated("use v2")`, violations[0].DiffSnippet)
+
+	ctx.RuleConfig["match_on"] = "both"
+	violations, err = checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Len(t, violations, 2)
+}
+
+func TestDiffPatternForbidden_InvalidMatchOn(t *testing.T) {
+	checker := &DiffPatternForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"src/Api.kt"},
+		RuleConfig: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`x`},
+			"match_on":          "context",
+		},
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "match_on")
+}
+
+func TestDiffPatternForbidden_ReportsLineNumbers(t *testing.T) {
+	diff := `diff --git a/domain/model/Price.kt b/domain/model/Price.kt
+--- a/domain/model/Price.kt
++++ b/domain/model/Price.kt
+@@ -10,3 +10,4 @@ data class Price(
+     val currency: String,
++    val amount: Double,
+ )`
+
+	checker := &DiffPatternForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/model/Price.kt"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`\bDouble\b`},
+		},
+		Severity: "warning",
+		RuleID:   "money_minor_units",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, 11, violations[0].Line)
+	assert.Equal(t, 11, violations[0].EndLine)
+}
+
+func TestDiffPatternForbidden_ChangeKinds(t *testing.T) {
+	diff := `diff --git a/src/new.go b/src/new.go
+new file mode 100644
+--- /dev/null
++++ b/src/new.go
+@@ -0,0 +1 @@
++// TODO: implement
+diff --git a/src/old.go b/src/old.go
+--- a/src/old.go
++++ b/src/old.go
+@@ -1 +1,2 @@
+ package src
++// TODO: later`
+
+	checker := &DiffPatternForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"src/new.go", "src/old.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`TODO`},
+			"change_kinds":      []interface{}{"added"},
+		},
+		Severity: "warning",
+		RuleID:   "no_todo_in_new_files",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "src/new.go", violations[0].FilePath)
+}
+
+func TestDiffPatternForbidden_StopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	checker := &DiffPatternForbiddenChecker{}
+	_, err := checker.Check(ctx, &CheckContext{
+		ChangedFiles: []string{"a.go"},
+		DiffContent:  "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -0,0 +1 @@\n+x\n",
+		RuleConfig:   map[string]interface{}{"forbidden_regexes": []interface{}{"x"}},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
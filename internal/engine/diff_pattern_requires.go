@@ -1,13 +1,15 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 )
 
 // DiffPatternRequiresChecker checks that when files matching only_in_paths are
 // changed, at least one of the required_regexes patterns appears somewhere in
-// the added lines of the diff. If none match, a violation is returned.
+// the added lines of the diff. If none match, a violation is returned. The
+// match_on option selects added lines (default), removed lines or both.
 type DiffPatternRequiresChecker struct{}
 
 // Type returns the checker type identifier.
@@ -16,19 +18,24 @@ func (c *DiffPatternRequiresChecker) Type() string {
 }
 
 // Check evaluates the diff_pattern_requires rule against the given context.
-func (c *DiffPatternRequiresChecker) Check(ctx *CheckContext) ([]Violation, error) {
-	requiredRegexes, err := getStringSlice(ctx.RuleConfig, "required_regexes")
+func (c *DiffPatternRequiresChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	requiredRegexes, err := getStringSlice(cc.RuleConfig, "required_regexes")
 	if err != nil {
 		return nil, fmt.Errorf("diff_pattern_requires: %w", err)
 	}
 
-	onlyInPaths, err := getStringSlice(ctx.RuleConfig, "only_in_paths")
+	onlyInPaths, err := getStringSlice(cc.RuleConfig, "only_in_paths")
+	if err != nil {
+		return nil, fmt.Errorf("diff_pattern_requires: %w", err)
+	}
+
+	matchOn, err := getMatchOn(cc.RuleConfig)
 	if err != nil {
 		return nil, fmt.Errorf("diff_pattern_requires: %w", err)
 	}
 
 	// Filter changed files matching only_in_paths.
-	matchedFiles := filterFilesByGlobs(ctx.ChangedFiles, onlyInPaths)
+	matchedFiles := filterFilesByGlobs(cc.ChangedFiles, onlyInPaths)
 	if len(matchedFiles) == 0 {
 		// Rule does not apply when no matching files are changed.
 		return nil, nil
@@ -37,19 +44,22 @@ func (c *DiffPatternRequiresChecker) Check(ctx *CheckContext) ([]Violation, erro
 	// Compile required regexes.
 	compiled := make([]*regexp.Regexp, 0, len(requiredRegexes))
 	for _, pattern := range requiredRegexes {
-		re, err := regexp.Compile(pattern)
+		re, err := compileRegex(pattern)
 		if err != nil {
 			return nil, fmt.Errorf("diff_pattern_requires: invalid regex %q: %w", pattern, err)
 		}
 		compiled = append(compiled, re)
 	}
 
-	// Parse diff and collect all added lines.
-	fileDiffs := ParseDiff(ctx.DiffContent)
-	for _, fd := range fileDiffs {
-		for _, addedLine := range fd.AddedLines {
+	// Parse diff and search the selected lines.
+	for _, fd := range cc.ParsedDiff().Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, line := range diffLines(fd, matchOn) {
 			for _, re := range compiled {
-				if re.MatchString(addedLine) {
+				if re.MatchString(line.Text[1:]) {
 					// Found a required pattern — no violation.
 					return nil, nil
 				}
@@ -60,9 +70,9 @@ func (c *DiffPatternRequiresChecker) Check(ctx *CheckContext) ([]Violation, erro
 	// None of the required patterns were found.
 	return []Violation{
 		{
-			RuleID:      ctx.RuleID,
-			Severity:    ctx.Severity,
-			Description: ctx.RuleDesc,
+			RuleID:      cc.RuleID,
+			Severity:    cc.Severity,
+			Description: cc.RuleDesc,
 			FilePath:    matchedFiles[0],
 			DiffSnippet: "",
 		},
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,7 @@ func TestDiffPatternRequires_PatternPresent(t *testing.T) {
 		RuleDesc: "Public API changes require RFC tag",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations, "should have no violations when required pattern is present")
 }
@@ -57,7 +58,7 @@ func TestDiffPatternRequires_PatternMissing(t *testing.T) {
 		RuleDesc: "Public API changes require RFC tag",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 1)
@@ -86,7 +87,7 @@ func TestDiffPatternRequires_NoMatchingFiles(t *testing.T) {
 		RuleDesc: "Public API changes require RFC tag",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations, "should have no violations when no files match only_in_paths")
 }
@@ -114,7 +115,7 @@ func TestDiffPatternRequires_MultipleRequiredRegexes(t *testing.T) {
 		RuleDesc: "Public API changes require RFC or BREAKING tag",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations, "should pass because BREAKING: matches one of the required regexes")
 }
@@ -138,7 +139,7 @@ func TestDiffPatternRequires_InvalidRegex(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid regex")
 }
@@ -161,7 +162,7 @@ func TestDiffPatternRequires_MissingRequiredRegexes(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "required_regexes")
 }
@@ -179,7 +180,7 @@ func TestDiffPatternRequires_MissingOnlyInPaths(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "only_in_paths")
 }
@@ -214,7 +215,40 @@ diff --git a/docs/changelog.md b/docs/changelog.md
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations, "RFC: found in diff (in changelog), so no violation")
 }
+
+func TestDiffPatternRequires_MatchOnRemoved(t *testing.T) {
+	diff := `diff --git a/pkg/store_test.go b/pkg/store_test.go
+--- a/pkg/store_test.go
++++ b/pkg/store_test.go
+@@ -1,4 +1,3 @@
+ func TestStore(t *testing.T) {
+-	t.Parallel()
+ 	run(t)
+ }`
+
+	checker := &DiffPatternRequiresChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"pkg/store_test.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"required_regexes": []interface{}{`t\.Parallel\(\)`},
+			"only_in_paths":    []interface{}{"**/*_test.go"},
+		},
+		Severity: "warning",
+		RuleID:   "parallel_tests",
+		RuleDesc: "Test changes must touch t.Parallel() calls",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Len(t, violations, 1, "removed lines are not searched by default")
+
+	ctx.RuleConfig["match_on"] = "removed"
+	violations, err = checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
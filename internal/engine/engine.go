@@ -1,25 +1,55 @@
 package engine
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
 )
 
 // Engine orchestrates rule checking by running all configured rules against
-// the given diff and filtering out exceptions.
+// the given diff and filtering out exceptions, inline suppressions and
+// baselined violations.
 type Engine struct {
 	Rules      []config.Rule
 	Exceptions []config.Exception
+	// Baseline lists known violations that are reported separately and do
+	// not count as errors or warnings.
+	Baseline []config.BaselineEntry
+	// SuppressionPolicy restricts inline guardian:ignore comments.
+	SuppressionPolicy config.SuppressionPolicy
+	// Repo, if set, is passed to checkers that need full file contents
+	// (e.g., go_imports_forbidden).
+	Repo RepoReader
+	// RepoRoot is passed to checkers as CheckContext.RepoRoot.
+	RepoRoot string
+	// Commits is passed to checkers as CheckContext.Commits.
+	Commits []git.Commit
+	// Identity is enforced on Commits by an IdentityChecker. Its violations
+	// are added after exceptions, inline suppressions and the baseline are
+	// applied, so that none of them can waive an identity check.
+	Identity config.Identity
+	// Workers bounds the number of rules checked concurrently. Zero means
+	// one worker per CPU.
+	Workers int
 }
 
 // EngineResult holds the aggregated results of running all rules.
 type EngineResult struct {
 	Violations []Violation
-	Errors     int
-	Warnings   int
+	Baselined  []Violation // violations suppressed by the baseline
+	// Suppressions lists the inline guardian:ignore comments that matched a
+	// violation, including rejected ones whose violation is still reported.
+	Suppressions []Suppression
+	RuleErrors   []RuleError // rules that failed to run, in rule order
+	Errors       int
+	Warnings     int
 }
 
 // NewEngine creates a new Engine with the given rules and exceptions.
@@ -31,39 +61,114 @@ func NewEngine(rules []config.Rule, exceptions []config.Exception) *Engine {
 }
 
 // Run executes all registered rule checkers against the changed files and diff
-// content, filters out exceptions, and returns the aggregated result.
-func (e *Engine) Run(changedFiles []string, diffContent string) (*EngineResult, error) {
-	var allViolations []Violation
-
-	for _, rule := range e.Rules {
+// content, filters out exceptions, inline suppressions and baselined
+// violations, and returns the aggregated result. A rule that fails (including
+// one with an unknown type or one that exceeds its timeout) is recorded in
+// EngineResult.RuleErrors and does not affect other rules. Disabled rules are
+// skipped. Run returns an error only if ctx is canceled.
+func (e *Engine) Run(ctx context.Context, changedFiles []string, diffContent string) (*EngineResult, error) {
+	checkers := make([]RuleChecker, len(e.Rules))
+	timeouts := make([]time.Duration, len(e.Rules))
+	errs := make([]error, len(e.Rules))
+	for i, rule := range e.Rules {
+		if !rule.IsEnabled() {
+			continue // neither run nor reported
+		}
 		checker, ok := Registry[rule.Type]
 		if !ok {
-			return nil, fmt.Errorf("unknown rule type %q for rule %q", rule.Type, rule.ID)
+			errs[i] = fmt.Errorf("unknown rule type %q", rule.Type)
+			continue
 		}
-
-		ctx := &CheckContext{
-			ChangedFiles: changedFiles,
-			DiffContent:  diffContent,
-			RuleConfig:   rule.Config,
-			Severity:     rule.Severity,
-			RuleID:       rule.ID,
-			RuleDesc:     rule.Description,
+		timeout, err := rule.TimeoutDuration()
+		if err != nil {
+			errs[i] = err
+			continue
 		}
+		checkers[i] = checker
+		timeouts[i] = timeout
+	}
 
-		violations, err := checker.Check(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("checking rule %q: %w", rule.ID, err)
+	// Parse the diff once; checkers share it read-only.
+	diff := NewParsedDiff(diffContent)
+
+	// Each rule writes only to its own slot, so the results keep rule order
+	// regardless of which worker finishes first.
+	results := make([][]Violation, len(e.Rules))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < e.workerCount(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				rule := e.Rules[i]
+				cc := &CheckContext{
+					ChangedFiles: changedFiles,
+					DiffContent:  diffContent,
+					Diff:         diff,
+					RuleConfig:   rule.Config,
+					Severity:     rule.Severity,
+					RuleID:       rule.ID,
+					RuleDesc:     rule.Description,
+					Repo:         e.Repo,
+					RepoRoot:     e.RepoRoot,
+					Commits:      e.Commits,
+				}
+				results[i], errs[i] = runChecker(ctx, checkers[i], cc, timeouts[i])
+			}
+		}()
+	}
+dispatch:
+	for i := range e.Rules {
+		if checkers[i] == nil {
+			continue
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
 		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("check canceled: %w", err)
+	}
 
-		allViolations = append(allViolations, violations...)
+	var allViolations []Violation
+	var ruleErrors []RuleError
+	for i, rule := range e.Rules {
+		if errs[i] != nil {
+			ruleErrors = append(ruleErrors, RuleError{
+				RuleID:   rule.ID,
+				RuleType: rule.Type,
+				Message:  errs[i].Error(),
+			})
+			continue
+		}
+		allViolations = append(allViolations, results[i]...)
 	}
 
-	// Filter out exceptions.
+	// Keep secrets out of every snippet, whichever rule reported it.
+	allViolations = e.redactViolations(allViolations)
+
+	// Filter out exceptions and inline suppressions, then set aside
+	// baselined violations.
 	filtered := e.applyExceptions(allViolations)
+	filtered, suppressions := e.applySuppressions(filtered, diff)
+	filtered, baselined := e.applyBaseline(filtered)
+
+	identityChecker := &IdentityChecker{Identity: e.Identity}
+	filtered = append(filtered, e.redactViolations(identityChecker.Check(e.Commits))...)
 
 	// Count errors and warnings.
 	result := &EngineResult{
-		Violations: filtered,
+		Violations:   filtered,
+		Baselined:    baselined,
+		Suppressions: suppressions,
+		RuleErrors:   ruleErrors,
 	}
 	for _, v := range filtered {
 		switch v.Severity {
@@ -77,6 +182,67 @@ func (e *Engine) Run(changedFiles []string, diffContent string) (*EngineResult,
 	return result, nil
 }
 
+// checkResult is the outcome of a single checker run.
+type checkResult struct {
+	violations []Violation
+	err        error
+}
+
+// runChecker runs a single checker with an optional timeout. The checker runs
+// in its own goroutine so that one which ignores ctx cannot block the check:
+// once the timeout expires or ctx is canceled, runChecker returns an error
+// and the checker's eventual result is discarded. A panic is converted into
+// an error so that a buggy checker cannot crash the whole run.
+func runChecker(ctx context.Context, c RuleChecker, cc *CheckContext, timeout time.Duration) ([]Violation, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan checkResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- checkResult{err: fmt.Errorf("checker panicked: %v", r)}
+			}
+		}()
+		violations, err := c.Check(ctx, cc)
+		done <- checkResult{violations: violations, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil && ctx.Err() != nil {
+			return nil, contextError(ctx, timeout)
+		}
+		return res.violations, res.err
+	case <-ctx.Done():
+		return nil, contextError(ctx, timeout)
+	}
+}
+
+// contextError describes why a checker's context ended: its own timeout
+// expired, or the whole check was canceled.
+func contextError(ctx context.Context, timeout time.Duration) error {
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return ctx.Err()
+}
+
+// workerCount returns the number of concurrent rule workers to start.
+func (e *Engine) workerCount() int {
+	n := e.Workers
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	if n > len(e.Rules) {
+		n = len(e.Rules)
+	}
+	return n
+}
+
 // applyExceptions removes violations that are covered by non-expired exceptions.
 func (e *Engine) applyExceptions(violations []Violation) []Violation {
 	now := time.Now()
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,7 +13,7 @@ import (
 
 func TestEngine_RunWithNoRules(t *testing.T) {
 	e := NewEngine(nil, nil)
-	result, err := e.Run([]string{"test.go"}, "")
+	result, err := e.Run(context.Background(), []string{"test.go"}, "")
 	require.NoError(t, err)
 	assert.Empty(t, result.Violations)
 	assert.Equal(t, 0, result.Errors)
@@ -42,7 +44,7 @@ func TestEngine_RunWithImportsForbidden(t *testing.T) {
 	}
 
 	e := NewEngine(rules, nil)
-	result, err := e.Run([]string{"domain/service/UserService.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/service/UserService.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Len(t, result.Violations, 1)
@@ -74,7 +76,7 @@ func TestEngine_RunWithDiffPatternForbidden(t *testing.T) {
 	}
 
 	e := NewEngine(rules, nil)
-	result, err := e.Run([]string{"domain/model/Price.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/model/Price.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Len(t, result.Violations, 1)
@@ -117,7 +119,7 @@ func TestEngine_RunWithMultipleRules(t *testing.T) {
 	}
 
 	e := NewEngine(rules, nil)
-	result, err := e.Run([]string{"domain/service/PaymentService.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/service/PaymentService.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Len(t, result.Violations, 2)
@@ -158,7 +160,7 @@ func TestEngine_ExceptionFiltersByRuleIDAndPath(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Empty(t, result.Violations, "violation should be filtered by exception")
@@ -199,7 +201,7 @@ func TestEngine_ExceptionDoesNotFilterDifferentRule(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Len(t, result.Violations, 1, "exception for different rule should not filter")
@@ -240,7 +242,7 @@ func TestEngine_ExpiredExceptionIsIgnored(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Len(t, result.Violations, 1, "expired exception should not filter violation")
@@ -281,7 +283,7 @@ func TestEngine_ActiveExceptionFiltersViolation(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Empty(t, result.Violations)
@@ -322,7 +324,7 @@ func TestEngine_PermanentException(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Empty(t, result.Violations, "permanent exception should filter violation")
@@ -339,9 +341,13 @@ func TestEngine_UnknownRuleType(t *testing.T) {
 	}
 
 	e := NewEngine(rules, nil)
-	_, err := e.Run([]string{"test.go"}, "")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "unknown rule type")
+	result, err := e.Run(context.Background(), []string{"test.go"}, "")
+	require.NoError(t, err)
+
+	require.Len(t, result.RuleErrors, 1)
+	assert.Equal(t, "unknown", result.RuleErrors[0].RuleID)
+	assert.Equal(t, "nonexistent_checker", result.RuleErrors[0].RuleType)
+	assert.Contains(t, result.RuleErrors[0].Message, "unknown rule type")
 }
 
 func TestEngine_ExceptionWithGlobPath(t *testing.T) {
@@ -379,8 +385,196 @@ func TestEngine_ExceptionWithGlobPath(t *testing.T) {
 	}
 
 	e := NewEngine(rules, exceptions)
-	result, err := e.Run([]string{"domain/legacy/OldAdapter.kt"}, diff)
+	result, err := e.Run(context.Background(), []string{"domain/legacy/OldAdapter.kt"}, diff)
 	require.NoError(t, err)
 
 	assert.Empty(t, result.Violations, "glob pattern exception should match")
 }
+
+func TestEngine_ParallelRunKeepsRuleOrder(t *testing.T) {
+	diff := `diff --git a/src/app.go b/src/app.go
+--- a/src/app.go
++++ b/src/app.go
+@@ -1 +1,2 @@
+ package src
++// TODO FIXME HACK XXX`
+
+	var rules []config.Rule
+	for i, word := range []string{"XXX", "HACK", "FIXME", "TODO", "XXX", "HACK"} {
+		rules = append(rules, config.Rule{
+			ID:   fmt.Sprintf("rule_%d_%s", i, word),
+			Type: "diff_pattern_forbidden",
+			Config: map[string]interface{}{
+				"forbidden_regexes": []interface{}{word},
+			},
+			Severity: "warning",
+		})
+	}
+
+	e := NewEngine(rules, nil)
+	e.Workers = 4
+
+	for run := 0; run < 20; run++ {
+		result, err := e.Run(context.Background(), []string{"src/app.go"}, diff)
+		require.NoError(t, err)
+		require.Len(t, result.Violations, len(rules))
+		for i, v := range result.Violations {
+			assert.Equal(t, rules[i].ID, v.RuleID)
+		}
+	}
+}
+
+func TestEngine_RuleErrorsDoNotStopOtherRules(t *testing.T) {
+	rules := []config.Rule{
+		{ID: "ok", Type: "diff_pattern_forbidden", Config: map[string]interface{}{"forbidden_regexes": []interface{}{"x"}}},
+		{ID: "bad_first", Type: "diff_pattern_forbidden", Config: map[string]interface{}{"forbidden_regexes": []interface{}{"[bad"}}},
+		{ID: "bad_second", Type: "diff_pattern_forbidden", Config: map[string]interface{}{}},
+	}
+
+	diff := `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1 +1,2 @@
+ package a
++x := 1`
+
+	e := NewEngine(rules, nil)
+	e.Workers = 3
+
+	result, err := e.Run(context.Background(), []string{"a.go"}, diff)
+	require.NoError(t, err)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "ok", result.Violations[0].RuleID)
+
+	require.Len(t, result.RuleErrors, 2)
+	assert.Equal(t, "bad_first", result.RuleErrors[0].RuleID)
+	assert.Contains(t, result.RuleErrors[0].Message, "invalid regex")
+	assert.Equal(t, "bad_second", result.RuleErrors[1].RuleID)
+	assert.Contains(t, result.RuleErrors[1].Message, "forbidden_regexes")
+}
+
+// panicChecker is a checker that always panics.
+type panicChecker struct{}
+
+func (c *panicChecker) Type() string { return "test_panic" }
+
+func (c *panicChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	panic("boom")
+}
+
+func TestEngine_CheckerPanicBecomesRuleError(t *testing.T) {
+	RegisterChecker(&panicChecker{})
+	t.Cleanup(func() { delete(Registry, "test_panic") })
+
+	e := NewEngine([]config.Rule{{ID: "panics", Type: "test_panic"}}, nil)
+	result, err := e.Run(context.Background(), nil, "")
+	require.NoError(t, err)
+
+	require.Len(t, result.RuleErrors, 1)
+	assert.Contains(t, result.RuleErrors[0].Message, "boom")
+}
+
+// blockingChecker is a checker that ignores its context and blocks until
+// release is closed.
+type blockingChecker struct {
+	release chan struct{}
+}
+
+func (c *blockingChecker) Type() string { return "test_blocking" }
+
+func (c *blockingChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	<-c.release
+	return nil, nil
+}
+
+func TestEngine_RuleTimeoutBecomesRuleError(t *testing.T) {
+	checker := &blockingChecker{release: make(chan struct{})}
+	RegisterChecker(checker)
+	t.Cleanup(func() {
+		close(checker.release)
+		delete(Registry, "test_blocking")
+	})
+
+	diff := `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,1 +1,2 @@
+ package a
++// TODO
+`
+	rules := []config.Rule{
+		{ID: "hangs", Type: "test_blocking", Severity: "error", Timeout: "50ms"},
+		{
+			ID:       "no_todo",
+			Type:     "diff_pattern_forbidden",
+			Severity: "error",
+			Config:   map[string]interface{}{"forbidden_regexes": []interface{}{"TODO"}},
+		},
+	}
+
+	e := NewEngine(rules, nil)
+	result, err := e.Run(context.Background(), []string{"a.go"}, diff)
+	require.NoError(t, err)
+
+	require.Len(t, result.RuleErrors, 1)
+	assert.Equal(t, "hangs", result.RuleErrors[0].RuleID)
+	assert.Equal(t, "timed out after 50ms", result.RuleErrors[0].Message)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "no_todo", result.Violations[0].RuleID)
+}
+
+func TestEngine_InvalidTimeoutBecomesRuleError(t *testing.T) {
+	rules := []config.Rule{
+		{ID: "bad_timeout", Type: "diff_pattern_forbidden", Timeout: "soon"},
+	}
+
+	e := NewEngine(rules, nil)
+	result, err := e.Run(context.Background(), nil, "")
+	require.NoError(t, err)
+
+	require.Len(t, result.RuleErrors, 1)
+	assert.Contains(t, result.RuleErrors[0].Message, `timeout "soon" is invalid`)
+}
+
+func TestEngine_DisabledRuleIsSkipped(t *testing.T) {
+	disabled := false
+	rules := []config.Rule{
+		{ID: "off", Type: "nonexistent_checker", Severity: "error", Enabled: &disabled},
+		{
+			ID:       "no_todo",
+			Type:     "diff_pattern_forbidden",
+			Config:   map[string]interface{}{"forbidden_regexes": []interface{}{"TODO"}},
+			Severity: "warning",
+			Enabled:  &disabled,
+		},
+	}
+
+	e := NewEngine(rules, nil)
+	result, err := e.Run(context.Background(), []string{"a.go"}, "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1,2 @@\n+// TODO")
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Violations)
+	assert.Empty(t, result.RuleErrors)
+}
+
+func TestEngine_CanceledContext(t *testing.T) {
+	checker := &blockingChecker{release: make(chan struct{})}
+	RegisterChecker(checker)
+	t.Cleanup(func() {
+		close(checker.release)
+		delete(Registry, "test_blocking")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	e := NewEngine([]config.Rule{{ID: "hangs", Type: "test_blocking"}}, nil)
+	_, err := e.Run(ctx, nil, "")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
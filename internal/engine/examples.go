@@ -0,0 +1,202 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+)
+
+// ExampleResult is the outcome of running one of a rule's examples.
+type ExampleResult struct {
+	RuleID  string
+	Index   int // position in the rule's examples list
+	Example config.RuleExample
+	// Violations are the violations the checker reported for the example.
+	Violations []Violation
+	// Err is set if the example is invalid or the checker failed.
+	Err error
+}
+
+// Passed reports whether the rule behaved as the example expects.
+func (r ExampleResult) Passed() bool {
+	if r.Err != nil {
+		return false
+	}
+	if r.Example.Expect == config.ExpectViolation {
+		return len(r.Violations) > 0
+	}
+	return len(r.Violations) == 0
+}
+
+// Failure describes why the example failed, or returns "" if it passed.
+func (r ExampleResult) Failure() string {
+	switch {
+	case r.Err != nil:
+		return r.Err.Error()
+	case r.Passed():
+		return ""
+	case r.Example.Expect == config.ExpectViolation:
+		return "expected a violation, got none"
+	default:
+		return fmt.Sprintf("expected no violation, got %d", len(r.Violations))
+	}
+}
+
+// Label names the example for reports, e.g., `example 2 ("double field")`.
+func (r ExampleResult) Label() string {
+	label := fmt.Sprintf("example %d", r.Index+1)
+	if r.Example.Name != "" {
+		label += fmt.Sprintf(" (%q)", r.Example.Name)
+	}
+	return label
+}
+
+// RunExamples runs each of the rule's examples through the rule's checker,
+// honouring the rule's timeout, and returns one result per example. An
+// example given as lines is checked as a newly added file whose content is
+// those lines. A diff only holds the whole content of the files it adds or
+// deletes, so rules that read other files fail on it with an error that
+// says so. An example given as commits is a commit range without a diff.
+// Disabled rules are run as well, so that their examples stay correct. It
+// returns an error only if ctx is canceled.
+func RunExamples(ctx context.Context, rule config.Rule, repoRoot string) ([]ExampleResult, error) {
+	results := make([]ExampleResult, 0, len(rule.Examples))
+
+	checker, ok := Registry[rule.Type]
+	timeout, timeoutErr := rule.TimeoutDuration()
+	for i, example := range rule.Examples {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result := ExampleResult{RuleID: rule.ID, Index: i, Example: example}
+		switch {
+		case !ok:
+			result.Err = fmt.Errorf("unknown rule type %q", rule.Type)
+		case timeoutErr != nil:
+			result.Err = timeoutErr
+		default:
+			result.Err = example.Validate()
+		}
+		if result.Err == nil {
+			cc := exampleContext(rule, example, repoRoot)
+			result.Violations, result.Err = runChecker(ctx, checker, cc, timeout)
+		}
+		results = append(results, result)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// exampleContext builds the check context for a single example.
+func exampleContext(rule config.Rule, example config.RuleExample, repoRoot string) *CheckContext {
+	diffContent := example.Diff
+	if len(example.Lines) > 0 {
+		diffContent = addedFileDiff(example.Path, example.Lines)
+	}
+	diff := NewParsedDiff(diffContent)
+
+	var repo RepoReader
+	switch {
+	case len(example.Lines) > 0:
+		repo = exampleRepo{path: example.Path, content: strings.Join(example.Lines, "\n") + "\n"}
+	case example.Diff != "":
+		repo = diffExampleRepo{diff: diff}
+	}
+
+	commits := make([]git.Commit, 0, len(example.Commits))
+	for i, message := range example.Commits {
+		commit := git.ParseCommitMessage(message)
+		commit.SHA = fmt.Sprintf("%040x", i+1)
+		commits = append(commits, commit)
+	}
+
+	changedFiles := make([]string, 0, len(diff.Files))
+	for _, fd := range diff.Files {
+		changedFiles = append(changedFiles, fd.Path)
+	}
+
+	return &CheckContext{
+		ChangedFiles: changedFiles,
+		DiffContent:  diffContent,
+		Diff:         diff,
+		RuleConfig:   rule.Config,
+		Severity:     rule.Severity,
+		RuleID:       rule.ID,
+		RuleDesc:     rule.Description,
+		Repo:         repo,
+		RepoRoot:     repoRoot,
+		Commits:      commits,
+	}
+}
+
+// addedFileDiff renders a unified diff that adds a file with the given lines.
+func addedFileDiff(path string, lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	b.WriteString("new file mode 100644\n")
+	b.WriteString("--- /dev/null\n")
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+	fmt.Fprintf(&b, "@@ -0,0 +1,%d @@\n", len(lines))
+	for _, line := range lines {
+		b.WriteString("+" + line + "\n")
+	}
+	return b.String()
+}
+
+// exampleRepo is the RepoReader of an example given as lines: the file is
+// new, so it exists only at the head revision.
+type exampleRepo struct {
+	path    string
+	content string
+}
+
+func (r exampleRepo) ReadBase(path string) ([]byte, error) {
+	return nil, fmt.Errorf("%s: not present before the example change", path)
+}
+
+func (r exampleRepo) ReadHead(path string) ([]byte, error) {
+	if path != r.path {
+		return nil, fmt.Errorf("%s: not part of the example", path)
+	}
+	return []byte(r.content), nil
+}
+
+// diffExampleRepo is the RepoReader of an example given as a diff. The diff
+// holds the whole base content of a file it deletes and the whole head
+// content of a file it adds, but only hunks of any other file.
+type diffExampleRepo struct {
+	diff *ParsedDiff
+}
+
+func (r diffExampleRepo) ReadBase(path string) ([]byte, error) {
+	fd, ok := r.diff.File(path)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%s: not part of the example", path)
+	case fd.Kind == ChangeAdded:
+		return nil, fmt.Errorf("%s: not present before the example change", path)
+	case fd.Kind != ChangeDeleted:
+		return nil, fmt.Errorf("%s: the example diff does not hold the whole file; only files the diff deletes can be read at the base revision", path)
+	}
+	return []byte(strings.Join(fd.RemovedLines, "\n") + "\n"), nil
+}
+
+func (r diffExampleRepo) ReadHead(path string) ([]byte, error) {
+	fd, ok := r.diff.File(path)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%s: not part of the example", path)
+	case fd.Kind == ChangeDeleted:
+		return nil, fmt.Errorf("%s: deleted by the example change", path)
+	case fd.Kind != ChangeAdded:
+		return nil, fmt.Errorf("%s: the example diff does not hold the whole file; give the example as lines, or as a diff that adds the file", path)
+	}
+	return []byte(strings.Join(fd.AddedLines, "\n") + "\n"), nil
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func moneyRuleWithExamples(examples ...config.RuleExample) config.Rule {
+	return config.Rule{
+		ID:          "money_minor_units",
+		Description: "Money must use int minor units",
+		Type:        "diff_pattern_forbidden",
+		Config: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`\bDouble\b`},
+			"only_in_paths":     []interface{}{"**/*.kt"},
+		},
+		Severity: "error",
+		Examples: examples,
+	}
+}
+
+func TestRunExamples_LinesAndDiff(t *testing.T) {
+	rule := moneyRuleWithExamples(
+		config.RuleExample{Name: "double field", Path: "domain/Price.kt", Lines: []string{"package domain", "val amount: Double = 0.0"}, Expect: "violation"},
+		config.RuleExample{Path: "domain/Price.kt", Lines: []string{"val amount: Long = 0"}, Expect: "pass"},
+		config.RuleExample{Name: "other language", Path: "web/price.ts", Lines: []string{"let amount: Double"}, Expect: "pass"},
+		config.RuleExample{
+			Name:   "removing a Double",
+			Diff:   "diff --git a/Price.kt b/Price.kt\n--- a/Price.kt\n+++ b/Price.kt\n@@ -1,2 +1 @@\n package domain\n-val amount: Double = 0.0\n",
+			Expect: "pass",
+		},
+	)
+
+	results, err := RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	for _, res := range results {
+		assert.True(t, res.Passed(), "%s: %s", res.Label(), res.Failure())
+	}
+	require.Len(t, results[0].Violations, 1)
+	assert.Equal(t, "domain/Price.kt", results[0].Violations[0].FilePath)
+	assert.Equal(t, 2, results[0].Violations[0].Line)
+}
+
+func TestRunExamples_Failures(t *testing.T) {
+	rule := moneyRuleWithExamples(
+		config.RuleExample{Name: "float", Path: "Price.kt", Lines: []string{"val amount: Float"}, Expect: "violation"},
+		config.RuleExample{Path: "Price.kt", Lines: []string{"val a: Double", "val b: Double"}, Expect: "pass"},
+		config.RuleExample{Lines: []string{"x"}, Expect: "pass"},
+	)
+
+	results, err := RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.False(t, results[0].Passed())
+	assert.Equal(t, `example 1 ("float")`, results[0].Label())
+	assert.Equal(t, "expected a violation, got none", results[0].Failure())
+
+	assert.False(t, results[1].Passed())
+	assert.Equal(t, "example 2", results[1].Label())
+	assert.Equal(t, "expected no violation, got 2", results[1].Failure())
+
+	assert.False(t, results[2].Passed())
+	assert.Equal(t, "path is required with lines", results[2].Failure())
+}
+
+func TestRunExamples_CheckerErrors(t *testing.T) {
+	example := config.RuleExample{Path: "a.kt", Lines: []string{"x"}, Expect: "pass"}
+
+	results, err := RunExamples(context.Background(), config.Rule{ID: "r", Type: "nonexistent", Examples: []config.RuleExample{example}}, "")
+	require.NoError(t, err)
+	assert.Contains(t, results[0].Failure(), `unknown rule type "nonexistent"`)
+
+	rule := moneyRuleWithExamples(example)
+	rule.Config = map[string]interface{}{"forbidden_regexes": []interface{}{"["}}
+	results, err = RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	assert.False(t, results[0].Passed())
+	assert.Contains(t, results[0].Failure(), "invalid regex")
+}
+
+func TestRunExamples_HeadContentForWholeFileCheckers(t *testing.T) {
+	rule := config.Rule{
+		ID:       "domain_no_infra",
+		Type:     "go_imports_forbidden",
+		Severity: "error",
+		Config: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"example.com/app/infra/**"},
+		},
+		Examples: []config.RuleExample{{
+			Path:   "domain/order.go",
+			Lines:  []string{"package domain", "", "import (", `	"fmt"`, `	"example.com/app/infra/db"`, ")"},
+			Expect: "violation",
+		}},
+	}
+
+	results, err := RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	assert.True(t, results[0].Passed(), results[0].Failure())
+}
+
+func TestRunExamples_DiffContents(t *testing.T) {
+	rule := config.Rule{
+		ID:       "deps",
+		Type:     "dependency_policy",
+		Severity: "error",
+		Config: map[string]interface{}{
+			"deny":            []interface{}{"left-pad"},
+			"forbid_removals": true,
+		},
+		Examples: []config.RuleExample{
+			{
+				Name:   "new manifest",
+				Diff:   "diff --git a/package.json b/package.json\nnew file mode 100644\n--- /dev/null\n+++ b/package.json\n@@ -0,0 +1,3 @@\n+{\n+  \"dependencies\": {\"left-pad\": \"1.3.0\"}\n+}\n",
+				Expect: "violation",
+			},
+			{
+				Name:   "deleted manifest",
+				Diff:   "diff --git a/requirements.txt b/requirements.txt\ndeleted file mode 100644\n--- a/requirements.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-django==4.2\n",
+				Expect: "violation",
+			},
+			{
+				Name:   "modified manifest",
+				Diff:   "diff --git a/go.mod b/go.mod\n--- a/go.mod\n+++ b/go.mod\n@@ -3 +3 @@\n-require a v1.0.0\n+require a v1.1.0\n",
+				Expect: "pass",
+			},
+		},
+	}
+
+	results, err := RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.True(t, results[0].Passed(), results[0].Failure())
+	assert.True(t, results[1].Passed(), results[1].Failure())
+	assert.False(t, results[2].Passed())
+	assert.Contains(t, results[2].Failure(), "go.mod: the example diff does not hold the whole file")
+}
+
+func TestRunExamples_Commits(t *testing.T) {
+	rule := config.Rule{
+		ID:       "commits",
+		Type:     "commit_message",
+		Severity: "error",
+		Config: map[string]interface{}{
+			"subject_regex":     `^(feat|fix): `,
+			"required_trailers": []interface{}{"Refs: ^[A-Z]+-[0-9]+$"},
+		},
+		Examples: []config.RuleExample{
+			{Commits: []string{"feat: orders\n\nRefs: SHOP-12"}, Expect: "pass"},
+			{Commits: []string{"fix: totals\n\nRefs: SHOP-13", "Update stuff"}, Expect: "violation"},
+			{Commits: []string{"fix: totals\n\nSee SHOP-13."}, Expect: "violation"},
+		},
+	}
+
+	results, err := RunExamples(context.Background(), rule, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, res := range results {
+		assert.True(t, res.Passed(), "%s: %s", res.Label(), res.Failure())
+	}
+	require.Len(t, results[1].Violations, 2)
+	assert.Equal(t, "0000000000000000000000000000000000000002", results[1].Violations[0].Commit)
+}
+
+func TestRunExamples_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := RunExamples(ctx, moneyRuleWithExamples(config.RuleExample{Path: "a.kt", Lines: []string{"x"}, Expect: "pass"}), "")
+	assert.ErrorIs(t, err, context.Canceled)
+}
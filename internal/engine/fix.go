@@ -0,0 +1,388 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+)
+
+// fixContextLines is the number of unchanged lines shown around each change
+// in a fix patch.
+const fixContextLines = 3
+
+// LineFix is a change a rule's fix makes to one line.
+type LineFix struct {
+	RuleID string
+	Line   int    // new-file line number
+	Old    string // line before the fix, without its line ending
+	New    string // line after the fix, without its line ending
+}
+
+// FileFix holds the fixed content of a file.
+type FileFix struct {
+	Path     string // slash-separated, relative to the repository root
+	Original []byte
+	Fixed    []byte
+	Lines    []LineFix // sorted by line
+}
+
+// SkippedFix is a violation of a rule with a fix that could not be fixed.
+type SkippedFix struct {
+	Violation Violation
+	Reason    string
+}
+
+// FixPlan is the set of changes that fixes violations, computed by PlanFixes
+// without touching any file.
+type FixPlan struct {
+	Files   []FileFix // sorted by path
+	Skipped []SkippedFix
+}
+
+// fixTarget is an added line that a rule's fix is applied to.
+type fixTarget struct {
+	violation int // index into the violations passed to PlanFixes
+	ruleID    string
+	line      int
+	added     string // the line's content according to the diff
+	re        *regexp.Regexp
+	replace   string
+}
+
+// PlanFixes computes the fixes for violations of rules that declare a fix.
+// Only the lines a violation spans that the diff adds are fixed, so that
+// unchanged code is never rewritten. The current content of each file is
+// obtained through read; a line whose content no longer matches the diff is
+// left alone. Violations of rules without a fix are ignored.
+func PlanFixes(rules []config.Rule, violations []Violation, diff *ParsedDiff, read func(path string) ([]byte, error)) *FixPlan {
+	fixes := make(map[string]*config.RuleFix, len(rules))
+	for i := range rules {
+		if rules[i].Fix != nil {
+			fixes[rules[i].ID] = rules[i].Fix
+		}
+	}
+
+	plan := &FixPlan{}
+	skip := func(v Violation, format string, args ...interface{}) {
+		plan.Skipped = append(plan.Skipped, SkippedFix{Violation: v, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	targets := map[string][]fixTarget{}
+	for i, v := range violations {
+		fix, ok := fixes[v.RuleID]
+		if !ok {
+			continue
+		}
+		if v.FilePath == "" || v.Line <= 0 {
+			skip(v, "the violation has no line")
+			continue
+		}
+		re, err := compileRegex(fix.Regex)
+		if err != nil {
+			skip(v, "invalid fix regex %q: %v", fix.Regex, err)
+			continue
+		}
+		fd, ok := diff.File(v.FilePath)
+		if !ok {
+			skip(v, "the file is not part of the diff")
+			continue
+		}
+
+		end := v.EndLine
+		if end < v.Line {
+			end = v.Line
+		}
+		found := false
+		for j, no := range fd.AddedLineNos {
+			if no >= v.Line && no <= end {
+				targets[v.FilePath] = append(targets[v.FilePath], fixTarget{
+					violation: i,
+					ruleID:    v.RuleID,
+					line:      no,
+					added:     fd.AddedLines[j],
+					re:        re,
+					replace:   fix.Replace,
+				})
+				found = true
+			}
+		}
+		if !found {
+			skip(v, "the violation is not on an added line")
+		}
+	}
+
+	paths := make([]string, 0, len(targets))
+	for path := range targets {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fileTargets := targets[path]
+		data, err := read(path)
+		if err != nil {
+			for _, t := range fileTargets {
+				skip(violations[t.violation], "reading file: %v", err)
+			}
+			continue
+		}
+
+		ff, skipped := fixFile(path, data, fileTargets)
+		for _, s := range skipped {
+			skip(violations[s.violation], "%s", s.reason)
+		}
+		if ff != nil {
+			plan.Files = append(plan.Files, *ff)
+		}
+	}
+
+	sort.SliceStable(plan.Skipped, func(i, j int) bool {
+		a, b := plan.Skipped[i].Violation, plan.Skipped[j].Violation
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.Line < b.Line
+	})
+	return plan
+}
+
+// skippedTarget is a violation none of whose lines could be fixed.
+type skippedTarget struct {
+	violation int
+	reason    string
+}
+
+// fixFile applies the targets to the content of one file. It returns nil if
+// no line changed, and the violations that could not be fixed at all.
+func fixFile(path string, data []byte, targets []fixTarget) (*FileFix, []skippedTarget) {
+	lines := splitLines(string(data))
+	original := make(map[int]string) // line -> content before any fix
+
+	fixed := map[int]bool{}     // violations with at least one changed line
+	reasons := map[int]string{} // violation -> why its last line was skipped
+	var lineFixes []LineFix
+	for _, t := range targets {
+		if t.line > len(lines) {
+			reasons[t.violation] = fmt.Sprintf("line %d is no longer in the file", t.line)
+			continue
+		}
+		body, eol := splitLineEnding(lines[t.line-1])
+		if _, ok := original[t.line]; !ok {
+			original[t.line] = body
+		}
+		if original[t.line] != strings.TrimSuffix(t.added, "\r") {
+			reasons[t.violation] = fmt.Sprintf("line %d has changed since the diff", t.line)
+			continue
+		}
+
+		replaced := t.re.ReplaceAllString(body, t.replace)
+		switch {
+		case strings.ContainsAny(replaced, "\r\n"):
+			reasons[t.violation] = fmt.Sprintf("the fix would split line %d", t.line)
+			continue
+		case replaced == body:
+			reasons[t.violation] = fmt.Sprintf("the fix does not change line %d", t.line)
+			continue
+		}
+
+		lines[t.line-1] = replaced + eol
+		lineFixes = append(lineFixes, LineFix{RuleID: t.ruleID, Line: t.line, Old: body, New: replaced})
+		fixed[t.violation] = true
+	}
+
+	var skipped []skippedTarget
+	seen := map[int]bool{}
+	for _, t := range targets {
+		if fixed[t.violation] || seen[t.violation] {
+			continue
+		}
+		seen[t.violation] = true
+		skipped = append(skipped, skippedTarget{violation: t.violation, reason: reasons[t.violation]})
+	}
+
+	if len(lineFixes) == 0 {
+		return nil, skipped
+	}
+	sort.SliceStable(lineFixes, func(i, j int) bool { return lineFixes[i].Line < lineFixes[j].Line })
+	return &FileFix{
+		Path:     path,
+		Original: data,
+		Fixed:    []byte(strings.Join(lines, "")),
+		Lines:    lineFixes,
+	}, skipped
+}
+
+// Paths returns the paths of the files the plan changes.
+func (p *FixPlan) Paths() []string {
+	paths := make([]string, 0, len(p.Files))
+	for _, f := range p.Files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+// Patch renders the plan as a unified diff that git apply accepts.
+func (p *FixPlan) Patch() string {
+	var b strings.Builder
+	for _, f := range p.Files {
+		writeFilePatch(&b, f)
+	}
+	return b.String()
+}
+
+// writeFilePatch writes the unified diff of one fixed file. Fixes replace
+// lines one for one, so both sides have the same number of lines.
+func writeFilePatch(b *strings.Builder, f FileFix) {
+	oldLines := splitLines(string(f.Original))
+	newLines := splitLines(string(f.Fixed))
+
+	changed := make(map[int]bool, len(f.Lines))
+	for _, lf := range f.Lines {
+		changed[lf.Line-1] = true
+	}
+
+	fmt.Fprintf(b, "diff --git a/%s b/%s\n", f.Path, f.Path)
+	fmt.Fprintf(b, "--- a/%s\n", f.Path)
+	fmt.Fprintf(b, "+++ b/%s\n", f.Path)
+
+	for _, h := range fixHunks(f.Lines, len(oldLines)) {
+		fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", h.start+1, h.end-h.start, h.start+1, h.end-h.start)
+		for i := h.start; i < h.end; {
+			if !changed[i] {
+				writePatchLine(b, " ", oldLines[i])
+				i++
+				continue
+			}
+			// Removals of a run of changed lines come before its additions.
+			j := i
+			for j < h.end && changed[j] {
+				j++
+			}
+			for k := i; k < j; k++ {
+				writePatchLine(b, "-", oldLines[k])
+			}
+			for k := i; k < j; k++ {
+				writePatchLine(b, "+", newLines[k])
+			}
+			i = j
+		}
+	}
+}
+
+// fixHunk is a range of 0-based line indexes [start, end) shown in a patch.
+type fixHunk struct {
+	start, end int
+}
+
+// fixHunks groups the fixed lines into hunks with fixContextLines lines of
+// context, merging hunks whose context overlaps or touches.
+func fixHunks(lineFixes []LineFix, total int) []fixHunk {
+	var hunks []fixHunk
+	for _, lf := range lineFixes {
+		start := lf.Line - 1 - fixContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := lf.Line + fixContextLines
+		if end > total {
+			end = total
+		}
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			if end > hunks[n-1].end {
+				hunks[n-1].end = end
+			}
+			continue
+		}
+		hunks = append(hunks, fixHunk{start: start, end: end})
+	}
+	return hunks
+}
+
+// writePatchLine writes a line of a hunk with its marker.
+func writePatchLine(b *strings.Builder, marker, line string) {
+	b.WriteString(marker)
+	if strings.HasSuffix(line, "\n") {
+		b.WriteString(line)
+		return
+	}
+	b.WriteString(line)
+	b.WriteString("\n\\ No newline at end of file\n")
+}
+
+// Apply writes the fixed files below root. Each file is replaced atomically
+// and keeps its permissions. A file whose content changed after the plan was
+// made is not written.
+func (p *FixPlan) Apply(root string) error {
+	for _, f := range p.Files {
+		path := filepath.Join(root, filepath.FromSlash(f.Path))
+		if err := writeFixedFile(path, f.Original, f.Fixed); err != nil {
+			return fmt.Errorf("fixing %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// writeFixedFile replaces the content of path with fixed, provided that it
+// still holds original. The content is written to a temporary file in the
+// same directory, which is then renamed over path.
+func writeFixedFile(path string, original, fixed []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, original) {
+		return fmt.Errorf("the file changed while the fix was planned")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".guardian-fix-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(fixed); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// splitLines splits content into lines that keep their line endings. The
+// last line has no ending if the content does not end with a newline.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitLineEnding separates a line from its "\n" or "\r\n" ending.
+func splitLineEnding(line string) (body, eol string) {
+	switch {
+	case strings.HasSuffix(line, "\r\n"):
+		return line[:len(line)-2], "\r\n"
+	case strings.HasSuffix(line, "\n"):
+		return line[:len(line)-1], "\n"
+	}
+	return line, ""
+}
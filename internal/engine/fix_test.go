@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixTestDiff = `diff --git a/domain/Price.kt b/domain/Price.kt
+--- a/domain/Price.kt
++++ b/domain/Price.kt
+@@ -1,3 +1,5 @@
+ package domain
++val amount: Double = 0.0
+ val legacy: Double = 0.0
++val tax: Double = 0.0
+ val rate: Int = 0
+`
+
+const fixTestFile = "package domain\nval amount: Double = 0.0\nval legacy: Double = 0.0\nval tax: Double = 0.0\nval rate: Int = 0\n"
+
+var fixTestRules = []config.Rule{
+	{
+		ID:          "money_minor_units",
+		Description: "Money must use int minor units",
+		Type:        "diff_pattern_forbidden",
+		Config: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`\bDouble\b`},
+		},
+		Severity: "error",
+		Fix:      &config.RuleFix{Regex: `: Double = 0\.0`, Replace: ": Long = 0"},
+	},
+}
+
+func readFiles(files map[string]string) func(string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("%s: no such file", path)
+		}
+		return []byte(content), nil
+	}
+}
+
+// planFixTest runs the rules over fixTestDiff and plans their fixes.
+func planFixTest(t *testing.T, rules []config.Rule, content string) *FixPlan {
+	t.Helper()
+	result, err := NewEngine(rules, nil).Run(context.Background(), []string{"domain/Price.kt"}, fixTestDiff)
+	require.NoError(t, err)
+	return PlanFixes(rules, result.Violations, NewParsedDiff(fixTestDiff), readFiles(map[string]string{"domain/Price.kt": content}))
+}
+
+func TestPlanFixes_OnlyAddedLines(t *testing.T) {
+	plan := planFixTest(t, fixTestRules, fixTestFile)
+
+	assert.Empty(t, plan.Skipped)
+	require.Len(t, plan.Files, 1)
+	f := plan.Files[0]
+	assert.Equal(t, "domain/Price.kt", f.Path)
+	assert.Equal(t, fixTestFile, string(f.Original))
+	assert.Equal(t, "package domain\nval amount: Long = 0\nval legacy: Double = 0.0\nval tax: Long = 0\nval rate: Int = 0\n", string(f.Fixed),
+		"the unchanged line 3 is left alone")
+	assert.Equal(t, []LineFix{
+		{RuleID: "money_minor_units", Line: 2, Old: "val amount: Double = 0.0", New: "val amount: Long = 0"},
+		{RuleID: "money_minor_units", Line: 4, Old: "val tax: Double = 0.0", New: "val tax: Long = 0"},
+	}, f.Lines)
+	assert.Equal(t, []string{"domain/Price.kt"}, plan.Paths())
+}
+
+func TestPlanFixes_Skipped(t *testing.T) {
+	// Line 2 was edited after the diff was taken and line 4 does not match
+	// the fix regex.
+	content := "package domain\nval amount: Double = 1.5\nval legacy: Double = 0.0\nval tax: Double = 0.0\nval rate: Int = 0\n"
+	rules := []config.Rule{fixTestRules[0]}
+	rules[0].Fix = &config.RuleFix{Regex: `Double = 0\.0$`, Replace: "Long = 0"}
+
+	plan := planFixTest(t, rules, content)
+	require.Len(t, plan.Files, 1)
+	assert.Len(t, plan.Files[0].Lines, 1)
+	assert.Equal(t, 4, plan.Files[0].Lines[0].Line)
+
+	require.Len(t, plan.Skipped, 1)
+	assert.Equal(t, 2, plan.Skipped[0].Violation.Line)
+	assert.Equal(t, "line 2 has changed since the diff", plan.Skipped[0].Reason)
+
+	rules[0].Fix = &config.RuleFix{Regex: `Float`, Replace: "Long"}
+	plan = planFixTest(t, rules, fixTestFile)
+	assert.Empty(t, plan.Files)
+	require.Len(t, plan.Skipped, 2)
+	assert.Equal(t, "the fix does not change line 2", plan.Skipped[0].Reason)
+
+	rules[0].Fix = &config.RuleFix{Regex: `Double`, Replace: "Long\n"}
+	plan = planFixTest(t, rules, fixTestFile)
+	assert.Empty(t, plan.Files)
+	assert.Equal(t, "the fix would split line 2", plan.Skipped[0].Reason)
+}
+
+func TestPlanFixes_IgnoresRulesWithoutFix(t *testing.T) {
+	rules := []config.Rule{fixTestRules[0]}
+	rules[0].Fix = nil
+
+	plan := planFixTest(t, rules, fixTestFile)
+	assert.Empty(t, plan.Files)
+	assert.Empty(t, plan.Skipped)
+}
+
+func TestPlanFixes_UnreadableFile(t *testing.T) {
+	result, err := NewEngine(fixTestRules, nil).Run(context.Background(), []string{"domain/Price.kt"}, fixTestDiff)
+	require.NoError(t, err)
+
+	plan := PlanFixes(fixTestRules, result.Violations, NewParsedDiff(fixTestDiff), readFiles(nil))
+	assert.Empty(t, plan.Files)
+	require.Len(t, plan.Skipped, 2)
+	assert.Contains(t, plan.Skipped[0].Reason, "reading file: domain/Price.kt: no such file")
+}
+
+func TestPlanFixes_KeepsLineEndings(t *testing.T) {
+	content := "package domain\r\nval amount: Double = 0.0\r\nval legacy: Double = 0.0\r\nval tax: Double = 0.0"
+	diff := "diff --git a/domain/Price.kt b/domain/Price.kt\n--- a/domain/Price.kt\n+++ b/domain/Price.kt\n@@ -1,2 +1,4 @@\n package domain\r\n+val amount: Double = 0.0\r\n val legacy: Double = 0.0\r\n+val tax: Double = 0.0\n\\ No newline at end of file\n"
+	violations := []Violation{
+		{RuleID: "money_minor_units", FilePath: "domain/Price.kt", Line: 2},
+		{RuleID: "money_minor_units", FilePath: "domain/Price.kt", Line: 4},
+	}
+
+	plan := PlanFixes(fixTestRules, violations, NewParsedDiff(diff), readFiles(map[string]string{"domain/Price.kt": content}))
+	assert.Empty(t, plan.Skipped)
+	require.Len(t, plan.Files, 1)
+	assert.Equal(t, "package domain\r\nval amount: Long = 0\r\nval legacy: Double = 0.0\r\nval tax: Long = 0", string(plan.Files[0].Fixed))
+}
+
+func TestFixPlan_Patch(t *testing.T) {
+	plan := planFixTest(t, fixTestRules, fixTestFile)
+
+	assert.Equal(t, `diff --git a/domain/Price.kt b/domain/Price.kt
+--- a/domain/Price.kt
++++ b/domain/Price.kt
+@@ -1,5 +1,5 @@
+ package domain
+-val amount: Double = 0.0
++val amount: Long = 0
+ val legacy: Double = 0.0
+-val tax: Double = 0.0
++val tax: Long = 0
+ val rate: Int = 0
+`, plan.Patch())
+}
+
+func TestFixPlan_PatchHunks(t *testing.T) {
+	var original, fixed string
+	for i := 1; i <= 20; i++ {
+		original += fmt.Sprintf("line %d\n", i)
+		fixed += fmt.Sprintf("line %d\n", i)
+	}
+	original += "x = 1"
+	fixed += "x = 2"
+	plan := &FixPlan{Files: []FileFix{{
+		Path:     "a.txt",
+		Original: []byte("line 0\n" + original),
+		Fixed:    []byte("LINE 0\n" + fixed),
+		Lines:    []LineFix{{Line: 1}, {Line: 22}},
+	}}}
+
+	assert.Equal(t, `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,4 +1,4 @@
+-line 0
++LINE 0
+ line 1
+ line 2
+ line 3
+@@ -19,4 +19,4 @@
+ line 18
+ line 19
+ line 20
+-x = 1
+\ No newline at end of file
++x = 2
+\ No newline at end of file
+`, plan.Patch())
+}
+
+func TestFixPlan_PatchAppliesWithGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "domain"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "domain", "Price.kt"), []byte(fixTestFile), 0644))
+
+	plan := planFixTest(t, fixTestRules, fixTestFile)
+	patch := filepath.Join(t.TempDir(), "fix.patch")
+	require.NoError(t, os.WriteFile(patch, []byte(plan.Patch()), 0644))
+
+	cmd := exec.Command("git", "apply", patch)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	data, err := os.ReadFile(filepath.Join(dir, "domain", "Price.kt"))
+	require.NoError(t, err)
+	assert.Equal(t, string(plan.Files[0].Fixed), string(data))
+}
+
+func TestFixPlan_Apply(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "domain", "Price.kt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(fixTestFile), 0600))
+
+	plan := planFixTest(t, fixTestRules, fixTestFile)
+	require.NoError(t, plan.Apply(dir))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(plan.Files[0].Fixed), string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+
+	// The file now differs from the planned original, so it is not written
+	// again.
+	err = plan.Apply(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fixing domain/Price.kt: the file changed while the fix was planned")
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// GoImportsForbiddenChecker checks that Go files matching from_globs do not
+// import packages matching forbid_globs. Unlike imports_forbidden, it parses
+// the changed files at the head revision with go/parser and inspects the real
+// import paths, so comments, string literals, aliased and grouped imports are
+// handled correctly. Only imports on lines added by the diff are reported.
+type GoImportsForbiddenChecker struct{}
+
+// goImport is a single import declaration found in a Go source file.
+type goImport struct {
+	Path string
+	Line int
+}
+
+// Type returns the checker type identifier.
+func (c *GoImportsForbiddenChecker) Type() string {
+	return "go_imports_forbidden"
+}
+
+// Check evaluates the go_imports_forbidden rule against the given context.
+func (c *GoImportsForbiddenChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	fromGlobs, err := getStringSlice(cc.RuleConfig, "from_globs")
+	if err != nil {
+		return nil, fmt.Errorf("go_imports_forbidden: %w", err)
+	}
+
+	forbidGlobs, err := getStringSlice(cc.RuleConfig, "forbid_globs")
+	if err != nil {
+		return nil, fmt.Errorf("go_imports_forbidden: %w", err)
+	}
+
+	diff := cc.ParsedDiff()
+
+	var violations []Violation
+
+	for _, file := range cc.ChangedFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !strings.HasSuffix(file, ".go") || !matchesAnyGlob(file, fromGlobs) {
+			continue
+		}
+
+		fd, ok := diff.File(file)
+		if !ok || len(fd.AddedLines) == 0 {
+			continue
+		}
+
+		if cc.Repo == nil {
+			return nil, fmt.Errorf("go_imports_forbidden: file contents are not available")
+		}
+
+		src, err := cc.Repo.ReadHead(file)
+		if err != nil {
+			return nil, fmt.Errorf("go_imports_forbidden: reading %s: %w", file, err)
+		}
+
+		imports, err := parseGoImports(file, src)
+		if err != nil {
+			return nil, fmt.Errorf("go_imports_forbidden: %w", err)
+		}
+
+		added := make(map[int]bool, len(fd.AddedLineNos))
+		for _, n := range fd.AddedLineNos {
+			added[n] = true
+		}
+
+		srcLines := strings.Split(string(src), "\n")
+		for _, imp := range imports {
+			if imp.Line < 1 || imp.Line > len(srcLines) {
+				continue
+			}
+			if !added[imp.Line] {
+				continue // import was not introduced by this diff
+			}
+			line := srcLines[imp.Line-1]
+			if !matchesImportPath(imp.Path, forbidGlobs) {
+				continue
+			}
+			violations = append(violations, Violation{
+				RuleID:      cc.RuleID,
+				Severity:    cc.Severity,
+				Description: cc.RuleDesc,
+				FilePath:    file,
+				Line:        imp.Line,
+				EndLine:     imp.Line,
+				DiffSnippet: "+" + line,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// parseGoImports parses the import declarations of a Go source file and
+// returns each import path with its line number. A file with syntax errors
+// after the import block still yields the imports that could be parsed.
+func parseGoImports(filename string, src []byte) ([]goImport, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly)
+	if f == nil {
+		return nil, fmt.Errorf("parsing %s: %w", filename, err)
+	}
+
+	imports := make([]goImport, 0, len(f.Imports))
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		imports = append(imports, goImport{
+			Path: path,
+			Line: fset.Position(spec.Path.Pos()).Line,
+		})
+	}
+	return imports, nil
+}
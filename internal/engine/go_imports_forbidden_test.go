@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapRepo is a RepoReader backed by in-memory maps.
+type mapRepo struct {
+	base map[string]string
+	head map[string]string
+}
+
+func (r *mapRepo) ReadBase(path string) ([]byte, error) {
+	return readFromMap(r.base, path)
+}
+
+func (r *mapRepo) ReadHead(path string) ([]byte, error) {
+	return readFromMap(r.head, path)
+}
+
+func readFromMap(files map[string]string, path string) ([]byte, error) {
+	content, ok := files[path]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", path)
+	}
+	return []byte(content), nil
+}
+
+// headFiles returns a RepoReader whose head revision holds the given files.
+func headFiles(files map[string]string) RepoReader {
+	return &mapRepo{head: files}
+}
+
+func TestGoImportsForbidden_GroupedAndAliasedImports(t *testing.T) {
+	diff := `diff --git a/domain/order/service.go b/domain/order/service.go
+--- a/domain/order/service.go
++++ b/domain/order/service.go
+@@ -1,6 +1,8 @@
+ package order
+
+ import (
+ 	"context"
++	db "github.com/acme/shop/infra/db"
++	"github.com/acme/shop/domain/money"
+ )
+`
+	head := `package order
+
+import (
+	"context"
+	db "github.com/acme/shop/infra/db"
+	"github.com/acme/shop/domain/money"
+)
+`
+
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/order/service.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		RuleDesc: "Domain layer must not depend on infra",
+		Repo:     headFiles(map[string]string{"domain/order/service.go": head}),
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "domain_no_infra", violations[0].RuleID)
+	assert.Equal(t, "domain/order/service.go", violations[0].FilePath)
+	assert.Equal(t, 5, violations[0].Line)
+	assert.Contains(t, violations[0].DiffSnippet, "infra/db")
+}
+
+func TestGoImportsForbidden_IgnoresCommentsAndStrings(t *testing.T) {
+	diff := `diff --git a/domain/order/service.go b/domain/order/service.go
+--- a/domain/order/service.go
++++ b/domain/order/service.go
+@@ -1,3 +1,5 @@
+ package order
+
++// See infra/db for the storage implementation.
++const table = "infra.orders"
+`
+	head := `package order
+
+// See infra/db for the storage implementation.
+const table = "infra.orders"
+`
+
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/order/service.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		Repo:     headFiles(map[string]string{"domain/order/service.go": head}),
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestGoImportsForbidden_ExistingImportNotReported(t *testing.T) {
+	diff := `diff --git a/domain/order/service.go b/domain/order/service.go
+--- a/domain/order/service.go
++++ b/domain/order/service.go
+@@ -4,3 +4,4 @@ import "github.com/acme/shop/infra/db"
+ func Load() {
++	db.Open()
+ }
+`
+	head := `package order
+
+import "github.com/acme/shop/infra/db"
+
+func Load() {
+	db.Open()
+}
+`
+
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/order/service.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		Repo:     headFiles(map[string]string{"domain/order/service.go": head}),
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestGoImportsForbidden_SkipsNonGoAndUnmatchedFiles(t *testing.T) {
+	diff := `diff --git a/domain/README.md b/domain/README.md
+--- a/domain/README.md
++++ b/domain/README.md
+@@ -1 +1,2 @@
+ # Domain
++import "github.com/acme/shop/infra/db"
+diff --git a/app/main.go b/app/main.go
+--- a/app/main.go
++++ b/app/main.go
+@@ -1,2 +1,3 @@
+ package main
++import "github.com/acme/shop/infra/db"
+`
+
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/README.md", "app/main.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		Repo:     headFiles(nil),
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestGoImportsForbidden_RequiresFileContents(t *testing.T) {
+	diff := `diff --git a/domain/order/service.go b/domain/order/service.go
+--- a/domain/order/service.go
++++ b/domain/order/service.go
+@@ -1,1 +1,2 @@
+ package order
++import "github.com/acme/shop/infra/db"
+`
+
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/order/service.go"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "file contents are not available")
+}
+
+func TestGoImportsForbidden_MissingConfig(t *testing.T) {
+	checker := &GoImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		RuleConfig: map[string]interface{}{
+			"from_globs": []interface{}{"domain/**"},
+		},
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "forbid_globs")
+}
+
+func TestGoImportsForbidden_Type(t *testing.T) {
+	checker := &GoImportsForbiddenChecker{}
+	assert.Equal(t, "go_imports_forbidden", checker.Type())
+}
+
+func TestMatchesImportPath(t *testing.T) {
+	assert.True(t, matchesImportPath("github.com/acme/shop/infra/db", []string{"infra/**"}))
+	assert.True(t, matchesImportPath("infra/db", []string{"infra/**"}))
+	assert.False(t, matchesImportPath("github.com/acme/shop/domain/money", []string{"infra/**"}))
+}
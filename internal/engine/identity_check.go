@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+)
+
+// identityRuleID is the rule ID of the violations reported by IdentityChecker.
+const identityRuleID = "identity_check"
+
+// signatureProblems describes the signature statuses reported by git's %G?
+// that never pass, i.e., all but "G" (good) and "U" (good, unknown
+// validity).
+var signatureProblems = map[string]string{
+	"N": "is not signed",
+	"B": "has a bad signature",
+	"X": "has an expired signature",
+	"Y": "is signed with an expired key",
+	"R": "is signed with a revoked key",
+	"E": "has a signature that cannot be checked (missing key or verifier)",
+}
+
+// IdentityChecker enforces the constitution's identity settings on commits:
+// author and committer emails must be in one of the allowed domains, and,
+// with require_signed_commits, each commit must carry a good GPG or SSH
+// signature, made by one of the trusted keys if any are listed.
+type IdentityChecker struct {
+	Identity config.Identity
+}
+
+// Check returns one violation per identity problem of each commit. Merge
+// commits are checked like any other.
+func (c *IdentityChecker) Check(commits []git.Commit) []Violation {
+	var violations []Violation
+	for _, commit := range commits {
+		for _, problem := range c.problems(commit) {
+			violations = append(violations, Violation{
+				RuleID:      identityRuleID,
+				Severity:    "error",
+				Description: problem,
+				Commit:      commit.SHA,
+				DiffSnippet: commit.Subject,
+			})
+		}
+	}
+	return violations
+}
+
+// problems returns the reasons, if any, why the commit breaks the identity
+// settings.
+func (c *IdentityChecker) problems(commit git.Commit) []string {
+	var problems []string
+
+	if len(c.Identity.AllowedDomains) > 0 {
+		if !c.allowedEmail(commit.AuthorEmail) {
+			problems = append(problems, fmt.Sprintf("Author %s is not in an allowed domain (%s)",
+				commit.AuthorEmail, strings.Join(c.Identity.AllowedDomains, ", ")))
+		}
+		if commit.CommitterEmail != commit.AuthorEmail && !c.allowedEmail(commit.CommitterEmail) {
+			problems = append(problems, fmt.Sprintf("Committer %s is not in an allowed domain (%s)",
+				commit.CommitterEmail, strings.Join(c.Identity.AllowedDomains, ", ")))
+		}
+	}
+
+	if c.Identity.RequireSignedCommits {
+		if problem := c.signatureProblem(commit); problem != "" {
+			problems = append(problems, "Commit "+problem)
+		}
+	}
+
+	return problems
+}
+
+// allowedEmail reports whether the domain of email is one of the allowed
+// domains. Domains compare case-insensitively; subdomains are not allowed
+// unless listed.
+func (c *IdentityChecker) allowedEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, allowed := range c.Identity.AllowedDomains {
+		if strings.EqualFold(domain, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureProblem describes what is wrong with the commit's signature, or
+// returns "" if it is acceptable. A good signature of unknown validity ("U")
+// is accepted only from a trusted key, since git cannot vouch for the key.
+func (c *IdentityChecker) signatureProblem(commit git.Commit) string {
+	if problem, ok := signatureProblems[commit.SignatureStatus]; ok {
+		return problem
+	}
+	if commit.SignatureStatus != "G" && commit.SignatureStatus != "U" {
+		return fmt.Sprintf("has an unknown signature status %q", commit.SignatureStatus)
+	}
+
+	trusted := c.trustedKey(commit)
+	switch {
+	case len(c.Identity.TrustedKeys) > 0 && !trusted:
+		return fmt.Sprintf("is signed with key %s, which is not in identity.trusted_keys", signingKeyName(commit))
+	case commit.SignatureStatus == "U" && !trusted:
+		return fmt.Sprintf("is signed with key %s, whose validity is unknown; add it to identity.trusted_keys", signingKeyName(commit))
+	}
+	return ""
+}
+
+// trustedKey reports whether the commit was signed with one of the trusted
+// keys, matched against the key ID and both fingerprints.
+func (c *IdentityChecker) trustedKey(commit git.Commit) bool {
+	for _, key := range c.Identity.TrustedKeys {
+		key = normalizeKeyID(key)
+		for _, id := range []string{commit.SigningKey, commit.SigningFingerprint, commit.PrimaryFingerprint} {
+			if id != "" && normalizeKeyID(id) == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeKeyID lets a GPG key ID or fingerprint be written in either case
+// and with spaces, as gpg prints it. SSH fingerprints are base64 and kept
+// as they are.
+func normalizeKeyID(id string) string {
+	if strings.HasPrefix(id, "SHA256:") {
+		return id
+	}
+	return strings.ToUpper(strings.ReplaceAll(id, " ", ""))
+}
+
+// signingKeyName names the key that signed the commit in violations.
+func signingKeyName(commit git.Commit) string {
+	for _, id := range []string{commit.SigningFingerprint, commit.SigningKey} {
+		if id != "" {
+			return id
+		}
+	}
+	return "(unknown)"
+}
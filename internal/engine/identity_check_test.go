@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/AlexGladkov/guardian-cli/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGPGFingerprint = "4AEE18F83AFDEB23A1B2C3D4E5F6071829304A5B"
+
+func signedCommit(sha, email, status string) git.Commit {
+	return git.Commit{
+		SHA:                sha,
+		AuthorEmail:        email,
+		CommitterEmail:     email,
+		SignatureStatus:    status,
+		SigningKey:         "E5F6071829304A5B",
+		SigningFingerprint: testGPGFingerprint,
+		PrimaryFingerprint: testGPGFingerprint,
+		Subject:            "commit " + sha,
+	}
+}
+
+func TestIdentityChecker_AllowedDomains(t *testing.T) {
+	checker := &IdentityChecker{Identity: config.Identity{AllowedDomains: []string{"company.com", "corp.io"}}}
+	commits := []git.Commit{
+		{SHA: "a", AuthorEmail: "dev@Company.com", CommitterEmail: "dev@Company.com"},
+		{SHA: "b", AuthorEmail: "dev@gmail.com", CommitterEmail: "dev@gmail.com"},
+		{SHA: "c", AuthorEmail: "dev@corp.io", CommitterEmail: "bot@ci.example.com", Subject: "chore: bump"},
+		{SHA: "d", AuthorEmail: "dev@eu.company.com", CommitterEmail: "dev@corp.io"},
+		{SHA: "e", AuthorEmail: "dev", CommitterEmail: "dev@corp.io"},
+	}
+
+	violations := checker.Check(commits)
+	require.Len(t, violations, 4)
+	assert.Equal(t, []string{
+		"Author dev@gmail.com is not in an allowed domain (company.com, corp.io)",
+		"Committer bot@ci.example.com is not in an allowed domain (company.com, corp.io)",
+		"Author dev@eu.company.com is not in an allowed domain (company.com, corp.io)",
+		"Author dev is not in an allowed domain (company.com, corp.io)",
+	}, descriptions(violations))
+
+	v := violations[1]
+	assert.Equal(t, "identity_check", v.RuleID)
+	assert.Equal(t, "error", v.Severity)
+	assert.Equal(t, "c", v.Commit)
+	assert.Equal(t, "chore: bump", v.DiffSnippet)
+	assert.Empty(t, v.FilePath)
+}
+
+func TestIdentityChecker_Signatures(t *testing.T) {
+	commits := []git.Commit{
+		signedCommit("good", "dev@company.com", "G"),
+		signedCommit("unknown", "dev@company.com", "U"),
+		signedCommit("unsigned", "dev@company.com", "N"),
+		signedCommit("bad", "dev@company.com", "B"),
+		signedCommit("missing", "dev@company.com", "E"),
+		signedCommit("revoked", "dev@company.com", "R"),
+	}
+
+	checker := &IdentityChecker{Identity: config.Identity{RequireSignedCommits: true}}
+	assert.Equal(t, []string{
+		"Commit is signed with key " + testGPGFingerprint + ", whose validity is unknown; add it to identity.trusted_keys",
+		"Commit is not signed",
+		"Commit has a bad signature",
+		"Commit has a signature that cannot be checked (missing key or verifier)",
+		"Commit is signed with a revoked key",
+	}, descriptions(checker.Check(commits)))
+
+	// A trusted key may be given as a key ID or a fingerprint, in any case
+	// and with spaces; it also accepts signatures of unknown validity.
+	for _, key := range []string{"e5f6071829304a5b", "4AEE 18F8 3AFD EB23 A1B2  C3D4 E5F6 0718 2930 4A5B"} {
+		checker.Identity.TrustedKeys = []string{key}
+		violations := checker.Check(commits[:2])
+		assert.Empty(t, violations, key)
+	}
+
+	checker.Identity.TrustedKeys = []string{"0000000000000000"}
+	assert.Equal(t, []string{
+		"Commit is signed with key " + testGPGFingerprint + ", which is not in identity.trusted_keys",
+		"Commit is signed with key " + testGPGFingerprint + ", which is not in identity.trusted_keys",
+	}, descriptions(checker.Check(commits[:2])))
+}
+
+func TestIdentityChecker_SSHKeys(t *testing.T) {
+	commit := git.Commit{
+		SHA:                "ssh",
+		SignatureStatus:    "G",
+		SigningKey:         "SHA256:VbPzrbMgm1pWnWJd2ieB3anJ3yxcphZRRLLJKkXq5ys",
+		SigningFingerprint: "SHA256:VbPzrbMgm1pWnWJd2ieB3anJ3yxcphZRRLLJKkXq5ys",
+	}
+	checker := &IdentityChecker{Identity: config.Identity{
+		RequireSignedCommits: true,
+		TrustedKeys:          []string{"SHA256:VbPzrbMgm1pWnWJd2ieB3anJ3yxcphZRRLLJKkXq5ys"},
+	}}
+	assert.Empty(t, checker.Check([]git.Commit{commit}))
+
+	// SSH fingerprints are base64, so case matters.
+	checker.Identity.TrustedKeys = []string{"SHA256:vbpzrbmgm1pwnwjd2ieb3anj3yxcphzrrlljkkxq5ys"}
+	assert.Len(t, checker.Check([]git.Commit{commit}), 1)
+}
+
+func TestIdentityChecker_NoChecks(t *testing.T) {
+	checker := &IdentityChecker{}
+	assert.Empty(t, checker.Check([]git.Commit{{SHA: "a", AuthorEmail: "dev@gmail.com", SignatureStatus: "N"}}))
+}
+
+func TestEngine_IdentityCannotBeWaived(t *testing.T) {
+	commit := git.Commit{SHA: "a", AuthorEmail: "dev@gmail.com", CommitterEmail: "dev@gmail.com", Subject: "feat: orders"}
+	identity := config.Identity{AllowedDomains: []string{"company.com"}}
+	violations := (&IdentityChecker{Identity: identity}).Check([]git.Commit{commit})
+	require.Len(t, violations, 1)
+
+	// Neither an exception for identity_check nor a baseline entry for the
+	// violation applies to it.
+	e := NewEngine(nil, []config.Exception{{RuleID: identityRuleID, Paths: []string{"*", "**"}}})
+	e.Baseline = []config.BaselineEntry{NewBaselineEntry(violations[0])}
+	e.Commits = []git.Commit{commit}
+	e.Identity = identity
+
+	result, err := e.Run(context.Background(), nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, violations, result.Violations)
+	assert.Empty(t, result.Baselined)
+	assert.Equal(t, 1, result.Errors)
+}
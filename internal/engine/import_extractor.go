@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ImportExtractor parses the import statements of one language family into
+// normalized module paths. Paths use "/" as separator regardless of the
+// language, so "com.myapp.infra.Db" becomes "com/myapp/infra/Db" and can be
+// matched against the same globs as file paths.
+type ImportExtractor interface {
+	// Extensions returns the file extensions handled by this extractor (e.g., ".kt").
+	Extensions() []string
+	// ExtractImports returns the module paths imported by a single source line,
+	// or nil if the line is not an import statement.
+	ExtractImports(line string) []string
+}
+
+// ImportExtractors maps file extensions to their import extractors.
+var ImportExtractors = map[string]ImportExtractor{}
+
+// RegisterImportExtractor registers an ImportExtractor for each of its extensions.
+func RegisterImportExtractor(e ImportExtractor) {
+	for _, ext := range e.Extensions() {
+		ImportExtractors[ext] = e
+	}
+}
+
+func init() {
+	RegisterImportExtractor(&jvmImportExtractor{})
+	RegisterImportExtractor(&esImportExtractor{})
+	RegisterImportExtractor(&pythonImportExtractor{})
+}
+
+// importExtractorFor returns the extractor registered for the file's
+// extension, or nil if the language is not supported.
+func importExtractorFor(path string) ImportExtractor {
+	return ImportExtractors[strings.ToLower(filepath.Ext(path))]
+}
+
+// jvmImportExtractor handles Kotlin, Java, Scala and Groovy imports:
+// "import a.b.C", "import a.b.*", "import static a.b.C.method" and
+// Kotlin aliases ("import a.b.C as D").
+type jvmImportExtractor struct{}
+
+var jvmImportRe = regexp.MustCompile(`^\s*import\s+(?:static\s+)?([A-Za-z_][\w.]*)`)
+
+func (e *jvmImportExtractor) Extensions() []string {
+	return []string{".kt", ".kts", ".java", ".scala", ".groovy"}
+}
+
+func (e *jvmImportExtractor) ExtractImports(line string) []string {
+	m := jvmImportRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	return []string{dottedToPath(m[1])}
+}
+
+// esImportExtractor handles JavaScript and TypeScript module references:
+// "import x from 'y'", "import 'y'", "export * from 'y'", the closing
+// "} from 'y'" line of a multi-line import, require('y') and import('y').
+type esImportExtractor struct{}
+
+var esImportRes = []*regexp.Regexp{
+	regexp.MustCompile(`^\s*(?:import|export|\})[^'"]*\bfrom\s+['"]([^'"]+)['"]`),
+	regexp.MustCompile(`^\s*import\s+['"]([^'"]+)['"]`),
+	regexp.MustCompile(`\brequire\(\s*['"]([^'"]+)['"]\s*\)`),
+	regexp.MustCompile(`\bimport\(\s*['"]([^'"]+)['"]\s*\)`),
+}
+
+func (e *esImportExtractor) Extensions() []string {
+	return []string{".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"}
+}
+
+func (e *esImportExtractor) ExtractImports(line string) []string {
+	var paths []string
+	for _, re := range esImportRes {
+		for _, m := range re.FindAllStringSubmatch(line, -1) {
+			if p := normalizeRelativePath(m[1]); p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// pythonImportExtractor handles "import a.b, c as d" and "from a.b import c",
+// including relative imports ("from ..infra import db").
+type pythonImportExtractor struct{}
+
+var (
+	pythonFromRe   = regexp.MustCompile(`^\s*from\s+(\.*[\w.]*)\s+import\b`)
+	pythonImportRe = regexp.MustCompile(`^\s*import\s+(.+)`)
+)
+
+func (e *pythonImportExtractor) Extensions() []string {
+	return []string{".py", ".pyi"}
+}
+
+func (e *pythonImportExtractor) ExtractImports(line string) []string {
+	if m := pythonFromRe.FindStringSubmatch(line); m != nil {
+		if p := dottedToPath(strings.TrimLeft(m[1], ".")); p != "" {
+			return []string{p}
+		}
+		return nil
+	}
+
+	m := pythonImportRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	// Drop trailing comments before splitting the module list.
+	modules := m[1]
+	if i := strings.Index(modules, "#"); i >= 0 {
+		modules = modules[:i]
+	}
+
+	var paths []string
+	for _, part := range strings.Split(modules, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		paths = append(paths, dottedToPath(fields[0]))
+	}
+	return paths
+}
+
+// dottedToPath converts a dotted module name to a slash-separated path,
+// dropping a trailing wildcard (e.g., "a.b.*" becomes "a/b").
+func dottedToPath(name string) string {
+	name = strings.TrimSuffix(name, ".*")
+	name = strings.TrimSuffix(name, ".")
+	return strings.ReplaceAll(name, ".", "/")
+}
+
+// normalizeRelativePath strips leading "./" and "../" segments from a module
+// specifier so that relative imports can be matched against directory globs.
+func normalizeRelativePath(p string) string {
+	for {
+		switch {
+		case strings.HasPrefix(p, "./"):
+			p = p[2:]
+		case strings.HasPrefix(p, "../"):
+			p = p[3:]
+		default:
+			return p
+		}
+	}
+}
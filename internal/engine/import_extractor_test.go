@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportExtractorFor(t *testing.T) {
+	assert.IsType(t, &jvmImportExtractor{}, importExtractorFor("domain/User.kt"))
+	assert.IsType(t, &jvmImportExtractor{}, importExtractorFor("src/Main.JAVA"))
+	assert.IsType(t, &esImportExtractor{}, importExtractorFor("web/app.tsx"))
+	assert.IsType(t, &pythonImportExtractor{}, importExtractorFor("svc/app.py"))
+	assert.Nil(t, importExtractorFor("main.go"))
+	assert.Nil(t, importExtractorFor("README"))
+}
+
+func TestJVMImportExtractor(t *testing.T) {
+	e := &jvmImportExtractor{}
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"import com.myapp.infra.database.UserRepository", []string{"com/myapp/infra/database/UserRepository"}},
+		{"import com.myapp.infra.*;", []string{"com/myapp/infra"}},
+		{"import static com.myapp.infra.Db.connect;", []string{"com/myapp/infra/Db/connect"}},
+		{"import com.myapp.infra.Db as Database", []string{"com/myapp/infra/Db"}},
+		{"// import com.myapp.infra.Db", nil},
+		{`val path = "com.myapp.infra.Db"`, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, e.ExtractImports(tt.line), tt.line)
+	}
+}
+
+func TestESImportExtractor(t *testing.T) {
+	e := &esImportExtractor{}
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`import { Db } from "../../infra/db";`, []string{"infra/db"}},
+		{`import Db from '@acme/infra';`, []string{"@acme/infra"}},
+		{`import "./polyfills";`, []string{"polyfills"}},
+		{`export * from './infra/db'`, []string{"infra/db"}},
+		{`} from "./infra/db";`, []string{"infra/db"}},
+		{`const db = require("./infra/db");`, []string{"infra/db"}},
+		{`const db = await import("./infra/db");`, []string{"infra/db"}},
+		{`// see infra/db.ts`, nil},
+		{`const label = "import from infra";`, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, e.ExtractImports(tt.line), tt.line)
+	}
+}
+
+func TestPythonImportExtractor(t *testing.T) {
+	e := &pythonImportExtractor{}
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"import os", []string{"os"}},
+		{"import app.infra.db as db, json  # storage", []string{"app/infra/db", "json"}},
+		{"from app.infra.db import Session", []string{"app/infra/db"}},
+		{"from ..infra import db", []string{"infra"}},
+		{"from . import models", nil},
+		{"# from app.infra import db", nil},
+		{`name = "app.infra.db"`, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, e.ExtractImports(tt.line), tt.line)
+	}
+}
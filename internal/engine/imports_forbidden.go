@@ -1,14 +1,17 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
 )
 
 // ImportsForbiddenChecker checks that files matching from_globs do not contain
-// imports from paths matching forbid_globs. It works by inspecting added lines
-// in the diff for path segments derived from the forbidden glob patterns.
+// imports from paths matching forbid_globs. For languages with a registered
+// ImportExtractor, added import statements are parsed into module paths and
+// matched against the globs. For other files it falls back to inspecting added
+// lines for path segments derived from the forbidden glob patterns.
 type ImportsForbiddenChecker struct{}
 
 // Type returns the checker type identifier.
@@ -17,25 +20,18 @@ func (c *ImportsForbiddenChecker) Type() string {
 }
 
 // Check evaluates the imports_forbidden rule against the given context.
-func (c *ImportsForbiddenChecker) Check(ctx *CheckContext) ([]Violation, error) {
-	fromGlobs, err := getStringSlice(ctx.RuleConfig, "from_globs")
+func (c *ImportsForbiddenChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	fromGlobs, err := getStringSlice(cc.RuleConfig, "from_globs")
 	if err != nil {
 		return nil, fmt.Errorf("imports_forbidden: %w", err)
 	}
 
-	forbidGlobs, err := getStringSlice(ctx.RuleConfig, "forbid_globs")
+	forbidGlobs, err := getStringSlice(cc.RuleConfig, "forbid_globs")
 	if err != nil {
 		return nil, fmt.Errorf("imports_forbidden: %w", err)
 	}
 
-	// Parse the diff into per-file diffs.
-	fileDiffs := ParseDiff(ctx.DiffContent)
-
-	// Build a map of file path to its diff for quick lookup.
-	diffMap := make(map[string]*FileDiff, len(fileDiffs))
-	for i := range fileDiffs {
-		diffMap[fileDiffs[i].Path] = &fileDiffs[i]
-	}
+	diff := cc.ParsedDiff()
 
 	// Extract forbidden path segments from forbid_globs.
 	// For example, "infra/**" yields "infra".
@@ -43,28 +39,40 @@ func (c *ImportsForbiddenChecker) Check(ctx *CheckContext) ([]Violation, error)
 
 	var violations []Violation
 
-	for _, file := range ctx.ChangedFiles {
+	for _, file := range cc.ChangedFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if !matchesAnyGlob(file, fromGlobs) {
 			continue
 		}
 
-		fd, ok := diffMap[file]
+		fd, ok := diff.File(file)
 		if !ok {
 			continue
 		}
 
-		for _, addedLine := range fd.AddedLines {
-			for _, segment := range forbidSegments {
-				if containsSegment(addedLine, segment) {
-					violations = append(violations, Violation{
-						RuleID:      ctx.RuleID,
-						Severity:    ctx.Severity,
-						Description: ctx.RuleDesc,
-						FilePath:    file,
-						DiffSnippet: "+" + addedLine,
-					})
-					break // one violation per line is enough
-				}
+		extractor := importExtractorFor(file)
+
+		for i, addedLine := range fd.AddedLines {
+			var forbidden bool
+			if extractor != nil {
+				forbidden = importsMatch(extractor.ExtractImports(addedLine), forbidGlobs)
+			} else {
+				forbidden = containsAnySegment(addedLine, forbidSegments)
+			}
+
+			if forbidden {
+				violations = append(violations, Violation{
+					RuleID:      cc.RuleID,
+					Severity:    cc.Severity,
+					Description: cc.RuleDesc,
+					FilePath:    file,
+					Line:        lineAt(fd.AddedLineNos, i),
+					EndLine:     lineAt(fd.AddedLineNos, i),
+					DiffSnippet: "+" + addedLine,
+				})
 			}
 		}
 	}
@@ -95,6 +103,26 @@ func containsSegment(line, segment string) bool {
 	return strings.Contains(line, segment+"/") || strings.Contains(line, segment+".")
 }
 
+// containsAnySegment checks if a line contains any of the given path segments.
+func containsAnySegment(line string, segments []string) bool {
+	for _, segment := range segments {
+		if containsSegment(line, segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// importsMatch checks if any of the given import paths matches the globs.
+func importsMatch(importPaths []string, globs []string) bool {
+	for _, p := range importPaths {
+		if matchesImportPath(p, globs) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesAnyGlob checks if a file path matches any of the given glob patterns.
 func matchesAnyGlob(file string, globs []string) bool {
 	for _, g := range globs {
@@ -105,6 +133,19 @@ func matchesAnyGlob(file string, globs []string) bool {
 	return false
 }
 
+// matchesImportPath checks if an import path matches any of the given glob
+// patterns. Besides the full path, every trailing sub-path is tried, so that
+// "infra/**" matches "github.com/acme/shop/infra/db" as well as "infra/db".
+func matchesImportPath(importPath string, globs []string) bool {
+	parts := strings.Split(importPath, "/")
+	for i := range parts {
+		if matchesAnyGlob(strings.Join(parts[i:], "/"), globs) {
+			return true
+		}
+	}
+	return false
+}
+
 // globMatch performs glob matching that supports "**" for recursive directory matching.
 // For patterns with "**", it splits on "**" and checks prefix/suffix or just presence
 // of the parts. For simple patterns, it delegates to filepath.Match.
@@ -179,3 +220,27 @@ func getStringSlice(cfg map[string]interface{}, key string) ([]string, error) {
 		return nil, fmt.Errorf("config key %q: expected string slice, got %T", key, val)
 	}
 }
+
+// getStringSliceMap extracts a map[string][]string from a
+// map[string]interface{} by key, e.g., a YAML mapping of names to glob lists.
+func getStringSliceMap(cfg map[string]interface{}, key string) (map[string][]string, error) {
+	val, ok := cfg[key]
+	if !ok {
+		return nil, fmt.Errorf("missing config key %q", key)
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config key %q: expected mapping, got %T", key, val)
+	}
+
+	result := make(map[string][]string, len(m))
+	for name := range m {
+		items, err := getStringSlice(m, name)
+		if err != nil {
+			return nil, fmt.Errorf("config key %q: %w", key, err)
+		}
+		result[name] = items
+	}
+	return result, nil
+}
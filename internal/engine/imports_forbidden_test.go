@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestImportsForbidden_BasicViolation(t *testing.T) {
 		RuleDesc: "Domain layer must not depend on infra",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 1)
@@ -63,7 +64,7 @@ func TestImportsForbidden_NoViolation_FileNotInFromGlobs(t *testing.T) {
 		RuleDesc: "Domain layer must not depend on infra",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
@@ -91,7 +92,7 @@ func TestImportsForbidden_NoViolation_NoForbiddenImport(t *testing.T) {
 		RuleDesc: "Domain layer must not depend on infra",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
@@ -120,7 +121,7 @@ func TestImportsForbidden_MultipleForbiddenGlobs(t *testing.T) {
 		RuleDesc: "Domain layer must not depend on infra or data",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 2)
@@ -160,7 +161,7 @@ diff --git a/domain/model/Order.kt b/domain/model/Order.kt
 		RuleDesc: "Domain layer must not depend on infra",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 
 	assert.Len(t, violations, 2)
@@ -179,7 +180,7 @@ func TestImportsForbidden_MissingConfig(t *testing.T) {
 		RuleDesc:     "test",
 	}
 
-	_, err := checker.Check(ctx)
+	_, err := checker.Check(context.Background(), ctx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "from_globs")
 }
@@ -203,7 +204,80 @@ func TestImportsForbidden_EmptyDiff(t *testing.T) {
 		RuleDesc: "test",
 	}
 
-	violations, err := checker.Check(ctx)
+	violations, err := checker.Check(context.Background(), ctx)
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
+
+func TestImportsForbidden_IgnoresNonImportLines(t *testing.T) {
+	diff := `diff --git a/domain/service/UserService.kt b/domain/service/UserService.kt
+--- a/domain/service/UserService.kt
++++ b/domain/service/UserService.kt
+@@ -1,3 +1,5 @@
+ package domain.service
++// Previously used com.myapp.infra.database.UserRepository
++val table = "infra.users"
+
+ class UserService {`
+
+	checker := &ImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/service/UserService.kt"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		RuleDesc: "Domain layer must not depend on infra",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestImportsForbidden_LanguageAwareImports(t *testing.T) {
+	diff := `diff --git a/domain/Order.java b/domain/Order.java
+--- a/domain/Order.java
++++ b/domain/Order.java
+@@ -1,1 +1,2 @@
+ package domain;
++import static com.myapp.infra.Db.connect;
+diff --git a/domain/order.ts b/domain/order.ts
+--- a/domain/order.ts
++++ b/domain/order.ts
+@@ -1,1 +1,3 @@
+ export {};
++import { Db } from "../infra/db";
++const q = require("../infra/queue");
+diff --git a/domain/order.py b/domain/order.py
+--- a/domain/order.py
++++ b/domain/order.py
+@@ -1,1 +1,2 @@
+ import os
++from app.infra.db import Session`
+
+	checker := &ImportsForbiddenChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/Order.java", "domain/order.ts", "domain/order.py"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"from_globs":   []interface{}{"domain/**"},
+			"forbid_globs": []interface{}{"infra/**"},
+		},
+		Severity: "error",
+		RuleID:   "domain_no_infra",
+		RuleDesc: "Domain layer must not depend on infra",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 4)
+	assert.Equal(t, "domain/Order.java", violations[0].FilePath)
+	assert.Equal(t, "domain/order.ts", violations[1].FilePath)
+	assert.Equal(t, "domain/order.ts", violations[2].FilePath)
+	assert.Equal(t, "domain/order.py", violations[3].FilePath)
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// LayersChecker enforces an architecture layer matrix. Each layer is declared
+// as a list of path globs, and allow lists the layers each layer may depend
+// on. An added import in a file of one layer that refers to another layer not
+// in its allow list is reported as a violation naming both layers. Imports
+// within the same layer are always allowed.
+type LayersChecker struct{}
+
+// Type returns the checker type identifier.
+func (c *LayersChecker) Type() string {
+	return "layers"
+}
+
+// Check evaluates the layers rule against the given context.
+func (c *LayersChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	layers, err := getStringSliceMap(cc.RuleConfig, "layers")
+	if err != nil {
+		return nil, fmt.Errorf("layers: %w", err)
+	}
+
+	allowed := map[string][]string{}
+	if _, ok := cc.RuleConfig["allow"]; ok {
+		allowed, err = getStringSliceMap(cc.RuleConfig, "allow")
+		if err != nil {
+			return nil, fmt.Errorf("layers: %w", err)
+		}
+	}
+
+	// Sort layer names so that overlapping globs resolve deterministically.
+	names := make([]string, 0, len(layers))
+	for name := range layers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	allowSet := make(map[string]map[string]bool, len(allowed))
+	for from, targets := range allowed {
+		if _, ok := layers[from]; !ok {
+			return nil, fmt.Errorf("layers: allow references unknown layer %q", from)
+		}
+		allowSet[from] = make(map[string]bool, len(targets))
+		for _, to := range targets {
+			if _, ok := layers[to]; !ok {
+				return nil, fmt.Errorf("layers: allow[%s] references unknown layer %q", from, to)
+			}
+			allowSet[from][to] = true
+		}
+	}
+
+	segments := make(map[string][]string, len(layers))
+	for name, globs := range layers {
+		segments[name] = extractPathSegments(globs)
+	}
+
+	diff := cc.ParsedDiff()
+
+	var violations []Violation
+
+	for _, file := range cc.ChangedFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		from := ""
+		for _, name := range names {
+			if matchesAnyGlob(file, layers[name]) {
+				from = name
+				break
+			}
+		}
+		if from == "" {
+			continue
+		}
+
+		fd, ok := diff.File(file)
+		if !ok {
+			continue
+		}
+
+		extractor := importExtractorFor(file)
+
+		for i, addedLine := range fd.AddedLines {
+			var targets []string
+			if extractor != nil {
+				for _, p := range extractor.ExtractImports(addedLine) {
+					for _, name := range names {
+						if matchesImportPath(p, layers[name]) {
+							targets = append(targets, name)
+							break
+						}
+					}
+				}
+			} else {
+				for _, name := range names {
+					if containsAnySegment(addedLine, segments[name]) {
+						targets = append(targets, name)
+					}
+				}
+			}
+
+			for _, to := range targets {
+				if to == from || allowSet[from][to] {
+					continue
+				}
+				violations = append(violations, Violation{
+					RuleID:      cc.RuleID,
+					Severity:    cc.Severity,
+					Description: fmt.Sprintf("%s (layer %q must not depend on layer %q)", cc.RuleDesc, from, to),
+					FilePath:    file,
+					Line:        lineAt(fd.AddedLineNos, i),
+					EndLine:     lineAt(fd.AddedLineNos, i),
+					DiffSnippet: "+" + addedLine,
+				})
+				break // one violation per line is enough
+			}
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cleanArchitectureConfig returns a four-layer configuration in which
+// dependencies may only point inward, towards the domain.
+func cleanArchitectureConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"layers": map[string]interface{}{
+			"domain":      []interface{}{"domain/**"},
+			"application": []interface{}{"application/**"},
+			"infra":       []interface{}{"infra/**"},
+			"ui":          []interface{}{"ui/**"},
+		},
+		"allow": map[string]interface{}{
+			"application": []interface{}{"domain"},
+			"infra":       []interface{}{"application", "domain"},
+			"ui":          []interface{}{"application", "domain"},
+		},
+	}
+}
+
+func TestLayers_DisallowedEdge(t *testing.T) {
+	diff := `diff --git a/domain/service/UserService.kt b/domain/service/UserService.kt
+--- a/domain/service/UserService.kt
++++ b/domain/service/UserService.kt
+@@ -1,3 +1,5 @@
+ package domain.service
++import com.myapp.infra.database.UserRepository
++import com.myapp.domain.model.User
+
+ class UserService {`
+
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"domain/service/UserService.kt"},
+		DiffContent:  diff,
+		RuleConfig:   cleanArchitectureConfig(),
+		Severity:     "error",
+		RuleID:       "clean_architecture",
+		RuleDesc:     "Dependencies must point inward",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "clean_architecture", violations[0].RuleID)
+	assert.Equal(t, "domain/service/UserService.kt", violations[0].FilePath)
+	assert.Contains(t, violations[0].Description, `layer "domain" must not depend on layer "infra"`)
+	assert.Contains(t, violations[0].DiffSnippet, "infra.database")
+}
+
+func TestLayers_AllowedEdges(t *testing.T) {
+	diff := `diff --git a/ui/screens/UserScreen.ts b/ui/screens/UserScreen.ts
+--- a/ui/screens/UserScreen.ts
++++ b/ui/screens/UserScreen.ts
+@@ -1,1 +1,3 @@
+ export {};
++import { GetUser } from "../../application/users";
++import { User } from "../../domain/user";
+diff --git a/infra/db/users.py b/infra/db/users.py
+--- a/infra/db/users.py
++++ b/infra/db/users.py
+@@ -1,1 +1,2 @@
+ import os
++from application.users import UserPort`
+
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"ui/screens/UserScreen.ts", "infra/db/users.py"},
+		DiffContent:  diff,
+		RuleConfig:   cleanArchitectureConfig(),
+		Severity:     "error",
+		RuleID:       "clean_architecture",
+		RuleDesc:     "Dependencies must point inward",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestLayers_FallbackForUnknownLanguage(t *testing.T) {
+	diff := `diff --git a/application/users/service.go b/application/users/service.go
+--- a/application/users/service.go
++++ b/application/users/service.go
+@@ -1,3 +1,4 @@
+ package users
+ import (
++	"github.com/acme/shop/ui/forms"
+ )`
+
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"application/users/service.go"},
+		DiffContent:  diff,
+		RuleConfig:   cleanArchitectureConfig(),
+		Severity:     "error",
+		RuleID:       "clean_architecture",
+		RuleDesc:     "Dependencies must point inward",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Description, `layer "application" must not depend on layer "ui"`)
+}
+
+func TestLayers_FileOutsideLayersIgnored(t *testing.T) {
+	diff := `diff --git a/scripts/seed.kt b/scripts/seed.kt
+--- a/scripts/seed.kt
++++ b/scripts/seed.kt
+@@ -1,1 +1,2 @@
+ package scripts
++import com.myapp.infra.database.UserRepository`
+
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"scripts/seed.kt"},
+		DiffContent:  diff,
+		RuleConfig:   cleanArchitectureConfig(),
+		Severity:     "error",
+		RuleID:       "clean_architecture",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestLayers_NoAllowMeansIsolatedLayers(t *testing.T) {
+	diff := `diff --git a/application/users/Service.kt b/application/users/Service.kt
+--- a/application/users/Service.kt
++++ b/application/users/Service.kt
+@@ -1,1 +1,2 @@
+ package application.users
++import com.myapp.domain.model.User`
+
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		ChangedFiles: []string{"application/users/Service.kt"},
+		DiffContent:  diff,
+		RuleConfig: map[string]interface{}{
+			"layers": map[string]interface{}{
+				"domain":      []interface{}{"domain/**"},
+				"application": []interface{}{"application/**"},
+			},
+		},
+		Severity: "error",
+		RuleID:   "isolated",
+	}
+
+	violations, err := checker.Check(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
+
+func TestLayers_UnknownLayerInAllow(t *testing.T) {
+	checker := &LayersChecker{}
+	ctx := &CheckContext{
+		RuleConfig: map[string]interface{}{
+			"layers": map[string]interface{}{
+				"domain": []interface{}{"domain/**"},
+			},
+			"allow": map[string]interface{}{
+				"domain": []interface{}{"persistence"},
+			},
+		},
+	}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown layer "persistence"`)
+}
+
+func TestLayers_MissingConfig(t *testing.T) {
+	checker := &LayersChecker{}
+	ctx := &CheckContext{RuleConfig: map[string]interface{}{}}
+
+	_, err := checker.Check(context.Background(), ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "layers")
+}
+
+func TestLayers_Type(t *testing.T) {
+	checker := &LayersChecker{}
+	assert.Equal(t, "layers", checker.Type())
+}
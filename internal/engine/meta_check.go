@@ -16,7 +16,11 @@ var protectedFiles = []string{
 // MetaChecker detects unauthorized changes to governance files
 // (.agreements/constitution.yml and .agreements/rules.yml). Changes to these
 // files without a corresponding accepted proposal produce a violation.
-type MetaChecker struct{}
+type MetaChecker struct {
+	// Protected lists additional repository-relative files governed like
+	// rules.yml, such as the scripts run by command rules.
+	Protected []string
+}
 
 // Check inspects the changed files for protected governance files and verifies
 // that a corresponding accepted proposal exists in the proposals directory.
@@ -24,7 +28,7 @@ func (m *MetaChecker) Check(changedFiles []string, proposalsDir string) ([]Viola
 	var violations []Violation
 
 	for _, file := range changedFiles {
-		if !isProtectedFile(file) {
+		if !m.isProtected(file) {
 			continue
 		}
 
@@ -47,6 +51,21 @@ func (m *MetaChecker) Check(changedFiles []string, proposalsDir string) ([]Viola
 	return violations, nil
 }
 
+// isProtected checks if the given file path is one of the protected
+// governance files or one of the checker's additional protected files.
+func (m *MetaChecker) isProtected(file string) bool {
+	if isProtectedFile(file) {
+		return true
+	}
+	normalized := filepath.ToSlash(file)
+	for _, p := range m.Protected {
+		if normalized == p {
+			return true
+		}
+	}
+	return false
+}
+
 // isProtectedFile checks if the given file path is one of the protected
 // governance files.
 func isProtectedFile(file string) bool {
@@ -144,3 +144,18 @@ func TestMetaCheck_OtherAgreementsFilesNotProtected(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, violations)
 }
+
+func TestMetaCheck_AdditionalProtectedFile(t *testing.T) {
+	checker := &MetaChecker{Protected: []string{"security/scan.py"}}
+	changedFiles := []string{
+		"security/scan.py",
+		"security/README.md",
+	}
+
+	violations, err := checker.Check(changedFiles, "/nonexistent")
+	require.NoError(t, err)
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "meta_check", violations[0].RuleID)
+	assert.Equal(t, "security/scan.py", violations[0].FilePath)
+}
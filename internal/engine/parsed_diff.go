@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"regexp"
+	"sync"
+)
+
+// ParsedDiff is a diff parsed once per engine run and shared by all checkers.
+// It must be treated as read-only, since checkers may run concurrently.
+type ParsedDiff struct {
+	Files  []FileDiff
+	byPath map[string]int
+}
+
+// NewParsedDiff parses unified diff content and indexes the files by path.
+func NewParsedDiff(diffContent string) *ParsedDiff {
+	files := ParseDiff(diffContent)
+	byPath := make(map[string]int, len(files))
+	for i := range files {
+		byPath[files[i].Path] = i
+	}
+	return &ParsedDiff{Files: files, byPath: byPath}
+}
+
+// File returns the diff of the file at path, if the diff contains it.
+func (d *ParsedDiff) File(path string) (*FileDiff, bool) {
+	i, ok := d.byPath[path]
+	if !ok {
+		return nil, false
+	}
+	return &d.Files[i], true
+}
+
+// Only returns a diff restricted to the file at path. It is empty if the
+// diff does not contain the file.
+func (d *ParsedDiff) Only(path string) *ParsedDiff {
+	fd, ok := d.File(path)
+	if !ok {
+		return &ParsedDiff{byPath: map[string]int{}}
+	}
+	return &ParsedDiff{Files: []FileDiff{*fd}, byPath: map[string]int{fd.Path: 0}}
+}
+
+// regexCache holds compiled patterns keyed by their source, so that each
+// rule's regexes are compiled once per process rather than once per run.
+var regexCache sync.Map // map[string]*regexp.Regexp
+
+// compileRegex returns the compiled form of pattern, compiling and caching it
+// on first use. It is safe for concurrent use.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewParsedDiff_FileLookup(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1 +1,2 @@
+ package a
++import "fmt"
+diff --git a/b.go b/b.go
+--- a/b.go
++++ b/b.go
+@@ -1 +1,2 @@
+ package b
++import "os"`
+
+	d := NewParsedDiff(diff)
+	require.Len(t, d.Files, 2)
+
+	fd, ok := d.File("b.go")
+	require.True(t, ok)
+	assert.Equal(t, []string{`import "os"`}, fd.AddedLines)
+
+	_, ok = d.File("missing.go")
+	assert.False(t, ok)
+}
+
+func TestParsedDiff_Only(t *testing.T) {
+	d := NewParsedDiff("diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1,2 @@\n+x\n" +
+		"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1 +1,2 @@\n+y\n")
+
+	only := d.Only("b.go")
+	require.Len(t, only.Files, 1)
+	fd, ok := only.File("b.go")
+	require.True(t, ok)
+	assert.Equal(t, []string{"y"}, fd.AddedLines)
+	_, ok = only.File("a.go")
+	assert.False(t, ok)
+
+	assert.Empty(t, d.Only("missing.go").Files)
+}
+
+func TestCheckContext_ParsedDiffFallback(t *testing.T) {
+	shared := NewParsedDiff("")
+	ctx := &CheckContext{Diff: shared}
+	assert.Same(t, shared, ctx.ParsedDiff())
+
+	ctx = &CheckContext{DiffContent: "diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1 +1,2 @@\n+y"}
+	fd, ok := ctx.ParsedDiff().File("x")
+	require.True(t, ok)
+	assert.Equal(t, []string{"y"}, fd.AddedLines)
+}
+
+func TestCompileRegex_Caches(t *testing.T) {
+	a, err := compileRegex(`\bDouble\b`)
+	require.NoError(t, err)
+	b, err := compileRegex(`\bDouble\b`)
+	require.NoError(t, err)
+	assert.Same(t, a, b)
+
+	_, err = compileRegex(`[invalid`)
+	assert.Error(t, err)
+}
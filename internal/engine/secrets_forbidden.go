@@ -0,0 +1,386 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+)
+
+// Defaults for the entropy scoring of quoted strings.
+const (
+	defaultSecretMinLength  = 20
+	defaultSecretMinEntropy = 4.0
+)
+
+// secretDetector recognizes one known token format.
+type secretDetector struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// secretDetectors are the known token formats, in reporting order. The
+// private key pattern also covers the key's body when the text contains it
+// (up to the END line, or else the base64 lines that follow the header, as
+// in a diff), so that redaction removes the whole block.
+var secretDetectors = []secretDetector{
+	{"aws_access_key_id", regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA)[0-9A-Z]{16}\b`)},
+	{"github_token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{"gitlab_token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20,}`)},
+	{"slack_token", regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9\-]{10,}`)},
+	{"google_api_key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"stripe_key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{8,}\.eyJ[A-Za-z0-9_\-]{8,}\.[A-Za-z0-9_\-]{8,}`)},
+	{"private_key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----(?:[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----|(?:\r?\n[+ ]?[A-Za-z0-9+/=]{16,})*)`)},
+}
+
+// highEntropyDetector is the name reported for quoted strings that look
+// random rather than matching a known format.
+const highEntropyDetector = "high_entropy_string"
+
+// redactedPattern matches the placeholder that redact puts in place of a
+// secret, so that redacting text twice leaves it unchanged.
+var redactedPattern = regexp.MustCompile(`\[REDACTED:[a-z_]+:[0-9a-f]{16}\]`)
+
+// quotedStringPattern matches single-, double- or backtick-quoted strings
+// without whitespace, whose content is scored for entropy.
+var quotedStringPattern = regexp.MustCompile("\"([^\"\\s]+)\"|'([^'\\s]+)'|`([^`\\s]+)`")
+
+// SecretsForbiddenChecker reports added lines that contain secrets: tokens
+// in a known format (cloud keys, JWTs, private key headers) or quoted
+// strings whose Shannon entropy suggests a random credential. Optionally
+// scoped to specific file path patterns via only_in_paths. Files matching
+// allow_paths and secrets whose fingerprint is listed in allow_fingerprints
+// are not reported. Secrets are redacted in the reported snippets.
+type SecretsForbiddenChecker struct{}
+
+// Type returns the checker type identifier.
+func (c *SecretsForbiddenChecker) Type() string {
+	return "secrets_forbidden"
+}
+
+// Check evaluates the secrets_forbidden rule against the given context.
+func (c *SecretsForbiddenChecker) Check(ctx context.Context, cc *CheckContext) ([]Violation, error) {
+	scanner, err := newSecretScanner(cc.RuleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("secrets_forbidden: %w", err)
+	}
+
+	onlyInPaths, _ := getStringSlice(cc.RuleConfig, "only_in_paths")
+	allowPaths, _ := getStringSlice(cc.RuleConfig, "allow_paths")
+	allowFingerprints, _ := getStringSlice(cc.RuleConfig, "allow_fingerprints")
+	allowed := make(map[string]bool, len(allowFingerprints))
+	for _, fp := range allowFingerprints {
+		allowed[fp] = true
+	}
+
+	var violations []Violation
+	for _, fd := range cc.ParsedDiff().Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(onlyInPaths) > 0 && !matchesAnyGlob(fd.Path, onlyInPaths) {
+			continue
+		}
+		if matchesAnyGlob(fd.Path, allowPaths) {
+			continue
+		}
+
+		for i, text := range fd.AddedLines {
+			var kinds []string
+			for _, m := range scanner.scan(text) {
+				if !allowed[m.fingerprint] && !containsString(kinds, m.detector) {
+					kinds = append(kinds, m.detector)
+				}
+			}
+			if len(kinds) == 0 {
+				continue
+			}
+
+			line := lineAt(fd.AddedLineNos, i)
+			violations = append(violations, Violation{
+				RuleID:      cc.RuleID,
+				Severity:    cc.Severity,
+				Description: fmt.Sprintf("%s (%s)", cc.RuleDesc, strings.Join(kinds, ", ")),
+				FilePath:    fd.Path,
+				Line:        line,
+				EndLine:     line,
+				DiffSnippet: "+" + scanner.redact(text),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// secretMatch is a secret found in a piece of text.
+type secretMatch struct {
+	detector    string
+	start, end  int // byte offsets of the secret in the text
+	fingerprint string
+	redacted    bool // the text is already a redaction placeholder
+}
+
+// secretScanner finds secrets with the detectors and entropy settings of a
+// secrets_forbidden rule.
+type secretScanner struct {
+	detectors  []secretDetector
+	entropy    bool
+	minLength  int
+	minEntropy float64
+}
+
+// newSecretScanner builds a scanner from a rule config. The optional keys
+// are detectors (names of the known formats to use, and
+// high_entropy_string for entropy scoring; all by default), min_length and
+// min_entropy (bits per character).
+func newSecretScanner(cfg map[string]interface{}) (*secretScanner, error) {
+	s := &secretScanner{detectors: secretDetectors, entropy: true}
+
+	if _, ok := cfg["detectors"]; ok {
+		names, err := getStringSlice(cfg, "detectors")
+		if err != nil {
+			return nil, err
+		}
+		s.detectors, s.entropy = nil, false
+		for _, name := range names {
+			if name == highEntropyDetector {
+				s.entropy = true
+				continue
+			}
+			d, ok := findSecretDetector(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown detector %q; must be one of: %s", name, strings.Join(secretDetectorNames(), ", "))
+			}
+			s.detectors = append(s.detectors, d)
+		}
+	}
+
+	minLength, err := getNumber(cfg, "min_length", defaultSecretMinLength)
+	if err != nil {
+		return nil, err
+	}
+	if minLength < 1 || minLength != math.Trunc(minLength) {
+		return nil, fmt.Errorf("config key %q: expected a positive integer, got %v", "min_length", minLength)
+	}
+	s.minLength = int(minLength)
+
+	s.minEntropy, err = getNumber(cfg, "min_entropy", defaultSecretMinEntropy)
+	if err != nil {
+		return nil, err
+	}
+	if s.minEntropy <= 0 {
+		return nil, fmt.Errorf("config key %q: expected a positive number, got %v", "min_entropy", s.minEntropy)
+	}
+	return s, nil
+}
+
+// scan returns the secrets in text, ordered by position. A quoted string
+// that contains a known token is reported only for the token.
+func (s *secretScanner) scan(text string) []secretMatch {
+	var matches []secretMatch
+	covered := func(start, end int) bool {
+		for _, m := range matches {
+			if start < m.end && m.start < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, loc := range redactedPattern.FindAllStringIndex(text, -1) {
+		matches = append(matches, secretMatch{start: loc[0], end: loc[1], redacted: true})
+	}
+	for _, d := range s.detectors {
+		for _, loc := range d.re.FindAllStringIndex(text, -1) {
+			if !covered(loc[0], loc[1]) {
+				matches = append(matches, newSecretMatch(d.name, text, loc[0], loc[1]))
+			}
+		}
+	}
+
+	if s.entropy {
+		for _, loc := range quotedStringPattern.FindAllStringSubmatchIndex(text, -1) {
+			// Exactly one of the three groups matched.
+			for g := 2; g < len(loc); g += 2 {
+				start, end := loc[g], loc[g+1]
+				if start < 0 || end-start < s.minLength || covered(start, end) {
+					continue
+				}
+				if shannonEntropy(text[start:end]) >= s.minEntropy {
+					matches = append(matches, newSecretMatch(highEntropyDetector, text, start, end))
+				}
+			}
+		}
+	}
+
+	secrets := matches[:0]
+	for _, m := range matches {
+		if !m.redacted {
+			secrets = append(secrets, m)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].start < secrets[j].start })
+	return secrets
+}
+
+// redact replaces each secret in text with a placeholder naming its
+// detector and fingerprint, e.g., [REDACTED:jwt:3f2a9c1d0e4b5a67].
+func (s *secretScanner) redact(text string) string {
+	matches := s.scan(text)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m.start])
+		fmt.Fprintf(&b, "[REDACTED:%s:%s]", m.detector, m.fingerprint)
+		last = m.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func newSecretMatch(detector, text string, start, end int) secretMatch {
+	return secretMatch{detector: detector, start: start, end: end, fingerprint: SecretFingerprint(text[start:end])}
+}
+
+// SecretFingerprint returns the identifier under which a secret is shown in
+// redacted output and can be listed in a secrets_forbidden rule's
+// allow_fingerprints: the first 16 hex digits of its SHA-256 hash.
+func SecretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func findSecretDetector(name string) (secretDetector, bool) {
+	for _, d := range secretDetectors {
+		if d.name == name {
+			return d, true
+		}
+	}
+	return secretDetector{}, false
+}
+
+// secretDetectorNames lists the names accepted in a rule's detectors key.
+func secretDetectorNames() []string {
+	names := make([]string, 0, len(secretDetectors)+1)
+	for _, d := range secretDetectors {
+		names = append(names, d.name)
+	}
+	return append(names, highEntropyDetector)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// getNumber extracts a number from a map[string]interface{} by key, or
+// returns def if the key is absent. YAML decodes numbers as int or float64.
+func getNumber(cfg map[string]interface{}, key string, def float64) (float64, error) {
+	val, ok := cfg[key]
+	if !ok {
+		return def, nil
+	}
+	switch v := val.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("config key %q: expected a number, got %T", key, val)
+	}
+}
+
+// RedactSecrets replaces the secrets that the enabled secrets_forbidden
+// rules among rules, including nested ones, find in text, such as a diff about to be sent to the
+// LLM. Text is returned unchanged if there is no such rule.
+func RedactSecrets(rules []config.Rule, text string) string {
+	for _, s := range secretScanners(rules) {
+		text = s.redactLines(text)
+	}
+	return text
+}
+
+// redactLines redacts text that may span several lines. Multi-line secrets
+// such as private keys are redacted as a whole, and the remaining secrets
+// line by line, so that quoted strings cannot span lines.
+func (s *secretScanner) redactLines(text string) string {
+	for _, d := range s.detectors {
+		if d.name != "private_key" {
+			continue
+		}
+		text = d.re.ReplaceAllStringFunc(text, func(block string) string {
+			return fmt.Sprintf("[REDACTED:%s:%s]", d.name, SecretFingerprint(block))
+		})
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = s.redact(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// redactViolations redacts the snippets of all violations, so that secrets
+// reported by other rules (e.g., a diff_pattern_forbidden rule matching
+// "password =") are not shown either.
+func (e *Engine) redactViolations(violations []Violation) []Violation {
+	scanners := secretScanners(e.Rules)
+	if len(scanners) == 0 {
+		return violations
+	}
+	for i := range violations {
+		for _, s := range scanners {
+			violations[i].DiffSnippet = s.redactLines(violations[i].DiffSnippet)
+		}
+	}
+	return violations
+}
+
+// secretScanners returns a scanner for each secrets_forbidden rule with a
+// valid config that is enabled or nested in an enabled composite rule.
+func secretScanners(rules []config.Rule) []*secretScanner {
+	var scanners []*secretScanner
+	walkRules(rules, func(top config.Rule, ruleType string, cfg map[string]interface{}) {
+		if ruleType != "secrets_forbidden" || !top.IsEnabled() {
+			return
+		}
+		if s, err := newSecretScanner(cfg); err == nil {
+			scanners = append(scanners, s)
+		}
+	})
+	return scanners
+}
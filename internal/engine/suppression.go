@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+// suppressionPattern matches an inline suppression comment such as
+//
+//	// guardian:ignore money_minor_units reason="legacy API returns Double"
+//
+// in any comment syntax. The reason is optional.
+var suppressionPattern = regexp.MustCompile(`guardian:ignore\s+([A-Za-z0-9_.\-]+)(?:\s+reason="([^"]*)")?`)
+
+// Suppression is an inline guardian:ignore comment that matches a violation.
+type Suppression struct {
+	Violation Violation
+	Line      int    // new-file line of the comment
+	Reason    string // text of reason="...", if given
+	// Rejected explains why the constitution does not allow the suppression.
+	// It is empty when the violation was suppressed; otherwise the violation
+	// is still reported.
+	Rejected string
+}
+
+// suppressionComment is a guardian:ignore comment parsed from a line.
+type suppressionComment struct {
+	ruleID string
+	reason string
+}
+
+// parseSuppressions returns the suppression comments on a line.
+func parseSuppressions(line string) []suppressionComment {
+	if !strings.Contains(line, "guardian:ignore") {
+		return nil
+	}
+
+	var comments []suppressionComment
+	for _, m := range suppressionPattern.FindAllStringSubmatch(line, -1) {
+		comments = append(comments, suppressionComment{
+			ruleID: m[1],
+			reason: strings.TrimSpace(m[2]),
+		})
+	}
+	return comments
+}
+
+// applySuppressions removes violations suppressed by a guardian:ignore comment
+// for their rule on the violation's first line or the line before it. It
+// returns the remaining violations and every suppression found, including
+// those the constitution's suppression policy rejects. Violations without a
+// line number cannot be suppressed inline.
+func (e *Engine) applySuppressions(violations []Violation, diff *ParsedDiff) ([]Violation, []Suppression) {
+	forbidden := make(map[string]bool, len(e.SuppressionPolicy.ForbiddenRules))
+	for _, id := range e.SuppressionPolicy.ForbiddenRules {
+		forbidden[id] = true
+	}
+
+	lines := &headLines{repo: e.Repo, diff: diff, files: map[string][]string{}}
+	kept := make([]Violation, 0, len(violations))
+	var suppressions []Suppression
+	for _, v := range violations {
+		s, ok := findSuppression(v, lines)
+		if !ok {
+			kept = append(kept, v)
+			continue
+		}
+
+		switch {
+		case forbidden[v.RuleID]:
+			s.Rejected = "inline suppression is not allowed for this rule"
+		case e.SuppressionPolicy.RequireReason && s.Reason == "":
+			s.Rejected = `a reason is required (reason="...")`
+		}
+		if s.Rejected != "" {
+			kept = append(kept, v)
+		}
+		suppressions = append(suppressions, s)
+	}
+
+	return kept, suppressions
+}
+
+// findSuppression looks for a suppression of v on its line or the line before.
+func findSuppression(v Violation, lines *headLines) (Suppression, bool) {
+	if v.FilePath == "" || v.Line <= 0 {
+		return Suppression{}, false
+	}
+
+	for _, n := range []int{v.Line, v.Line - 1} {
+		text, ok := lines.line(v.FilePath, n)
+		if !ok {
+			continue
+		}
+		for _, c := range parseSuppressions(text) {
+			if c.ruleID == v.RuleID {
+				return Suppression{Violation: v, Line: n, Reason: c.reason}, true
+			}
+		}
+	}
+	return Suppression{}, false
+}
+
+// headLines looks up lines of files at the head revision. It reads whole
+// files through the RepoReader when available and otherwise falls back to
+// the lines added by the diff.
+type headLines struct {
+	repo  RepoReader
+	diff  *ParsedDiff
+	files map[string][]string // path -> lines, nil if unreadable
+}
+
+// line returns the 1-based line n of path.
+func (h *headLines) line(path string, n int) (string, bool) {
+	if n <= 0 {
+		return "", false
+	}
+
+	if h.repo != nil {
+		lines, ok := h.files[path]
+		if !ok {
+			if data, err := h.repo.ReadHead(path); err == nil {
+				lines = strings.Split(string(data), "\n")
+			}
+			h.files[path] = lines
+		}
+		if lines != nil {
+			if n > len(lines) {
+				return "", false
+			}
+			return lines[n-1], true
+		}
+	}
+
+	fd, ok := h.diff.File(path)
+	if !ok {
+		return "", false
+	}
+	for i, no := range fd.AddedLineNos {
+		if no == n {
+			return fd.AddedLines[i], true
+		}
+	}
+	return "", false
+}
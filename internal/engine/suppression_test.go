@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AlexGladkov/guardian-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const suppressionTestDiff = `diff --git a/domain/Price.kt b/domain/Price.kt
+--- a/domain/Price.kt
++++ b/domain/Price.kt
+@@ -1,2 +1,6 @@
+ package domain
++// guardian:ignore money_minor_units reason="legacy API returns floats"
++val legacy: Double = 0.0
++val rate: Double = 0.0 // guardian:ignore money_minor_units
++// guardian:ignore other_rule reason="wrong rule"
++val tax: Double = 0.0`
+
+var suppressionTestRules = []config.Rule{
+	{
+		ID:          "money_minor_units",
+		Description: "Money must use int minor units",
+		Type:        "diff_pattern_forbidden",
+		Config: map[string]interface{}{
+			"forbidden_regexes": []interface{}{`\bDouble\b`},
+		},
+		Severity: "error",
+	},
+}
+
+func TestParseSuppressions(t *testing.T) {
+	assert.Nil(t, parseSuppressions("val x = 1"))
+	assert.Equal(t,
+		[]suppressionComment{{ruleID: "a_rule", reason: "why not"}, {ruleID: "b.rule"}},
+		parseSuppressions(`# guardian:ignore a_rule reason=" why not " guardian:ignore b.rule`),
+	)
+}
+
+func TestEngine_InlineSuppressions(t *testing.T) {
+	e := NewEngine(suppressionTestRules, nil)
+
+	result, err := e.Run(context.Background(), []string{"domain/Price.kt"}, suppressionTestDiff)
+	require.NoError(t, err)
+
+	require.Len(t, result.Violations, 1, "a comment for another rule does not suppress")
+	assert.Equal(t, 6, result.Violations[0].Line)
+	assert.Equal(t, 1, result.Errors)
+
+	require.Len(t, result.Suppressions, 2)
+	assert.Equal(t, 3, result.Suppressions[0].Violation.Line)
+	assert.Equal(t, 2, result.Suppressions[0].Line, "comment on the preceding line")
+	assert.Equal(t, "legacy API returns floats", result.Suppressions[0].Reason)
+	assert.Empty(t, result.Suppressions[0].Rejected)
+	assert.Equal(t, 4, result.Suppressions[1].Line, "comment on the same line")
+	assert.Empty(t, result.Suppressions[1].Reason)
+}
+
+func TestEngine_InlineSuppressionsRequireReason(t *testing.T) {
+	e := NewEngine(suppressionTestRules, nil)
+	e.SuppressionPolicy = config.SuppressionPolicy{RequireReason: true}
+
+	result, err := e.Run(context.Background(), []string{"domain/Price.kt"}, suppressionTestDiff)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Violations, 2)
+	require.Len(t, result.Suppressions, 2)
+	assert.Empty(t, result.Suppressions[0].Rejected)
+	assert.Contains(t, result.Suppressions[1].Rejected, "a reason is required")
+}
+
+func TestEngine_InlineSuppressionsForbiddenRule(t *testing.T) {
+	e := NewEngine(suppressionTestRules, nil)
+	e.SuppressionPolicy = config.SuppressionPolicy{ForbiddenRules: []string{"money_minor_units"}}
+
+	result, err := e.Run(context.Background(), []string{"domain/Price.kt"}, suppressionTestDiff)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Violations, 3)
+	assert.Equal(t, 3, result.Errors)
+	require.Len(t, result.Suppressions, 2)
+	for _, s := range result.Suppressions {
+		assert.Equal(t, "inline suppression is not allowed for this rule", s.Rejected)
+	}
+}
+
+func TestEngine_InlineSuppressionsReadHeadFile(t *testing.T) {
+	// The comment is on an unchanged line, so only the full file shows it.
+	diff := `diff --git a/domain/Price.kt b/domain/Price.kt
+--- a/domain/Price.kt
++++ b/domain/Price.kt
+@@ -1,2 +1,3 @@
+ package domain
+ // guardian:ignore money_minor_units reason="kept for the v1 API"
++val legacy: Double = 0.0`
+
+	e := NewEngine(suppressionTestRules, nil)
+	result, err := e.Run(context.Background(), []string{"domain/Price.kt"}, diff)
+	require.NoError(t, err)
+	assert.Len(t, result.Violations, 1, "the diff alone does not contain the comment line")
+
+	e.Repo = headFiles(map[string]string{
+		"domain/Price.kt": "package domain\n// guardian:ignore money_minor_units reason=\"kept for the v1 API\"\nval legacy: Double = 0.0\n",
+	})
+	result, err = e.Run(context.Background(), []string{"domain/Price.kt"}, diff)
+	require.NoError(t, err)
+	assert.Empty(t, result.Violations)
+	require.Len(t, result.Suppressions, 1)
+	assert.Equal(t, "kept for the v1 API", result.Suppressions[0].Reason)
+}
@@ -17,16 +17,63 @@ type DiffResult struct {
 
 // GetDiff runs git diff for the given range and returns changed files along with
 // the full unified diff content. The diffRange should be in the form "base..head".
+// Rename detection is enabled, so a moved file is reported once under its new
+// path with "rename from"/"rename to" headers.
 func GetDiff(diffRange string) (*DiffResult, error) {
-	files, err := GetDiffNameOnly(diffRange)
+	return getDiff(diffRange)
+}
+
+// GetStagedDiff returns the changes staged in the index relative to HEAD,
+// i.e., what the next commit would contain.
+func GetStagedDiff() (*DiffResult, error) {
+	return getDiff("--cached")
+}
+
+// GetWorktreeDiff returns all uncommitted changes in the working tree,
+// staged or not, relative to HEAD. Untracked files are not included.
+func GetWorktreeDiff() (*DiffResult, error) {
+	return getDiff("HEAD")
+}
+
+// GetTreeDiff returns the diff of every file tracked at rev against the empty
+// tree, so that each file appears as newly added with all of its lines. If
+// globs are given, only files matching at least one of them are included.
+func GetTreeDiff(rev string, globs []string) (*DiffResult, error) {
+	emptyTree, err := EmptyTree()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{emptyTree, rev, "--"}
+	for _, g := range globs {
+		args = append(args, ":(glob)"+g)
+	}
+	return getDiff(args...)
+}
+
+// EmptyTree returns the object name of the empty tree in the current
+// repository's hash format.
+func EmptyTree() (string, error) {
+	cmd := exec.Command("git", "hash-object", "-t", "tree", "/dev/null")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running git hash-object: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// getDiff runs git diff with rename detection for the given revision and
+// pathspec arguments.
+func getDiff(args ...string) (*DiffResult, error) {
+	files, err := diffNameOnly(args...)
 	if err != nil {
 		return nil, fmt.Errorf("getting changed files: %w", err)
 	}
 
-	cmd := exec.Command("git", "diff", diffRange)
+	cmd := exec.Command("git", append([]string{"diff", "-M"}, args...)...)
 	out, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("running git diff %s: %w", diffRange, err)
+		return nil, fmt.Errorf("running git diff %s: %w", strings.Join(args, " "), err)
 	}
 
 	return &DiffResult{
@@ -36,12 +83,19 @@ func GetDiff(diffRange string) (*DiffResult, error) {
 }
 
 // GetDiffNameOnly runs git diff --name-only for the given range and returns
-// the list of changed file paths.
+// the list of changed file paths. Deleted files are listed under their old
+// path and renamed files under their new path.
 func GetDiffNameOnly(diffRange string) ([]string, error) {
-	cmd := exec.Command("git", "diff", "--name-only", diffRange)
+	return diffNameOnly(diffRange)
+}
+
+// diffNameOnly runs git diff --name-only with rename detection for the given
+// revision and pathspec arguments.
+func diffNameOnly(args ...string) ([]string, error) {
+	cmd := exec.Command("git", append([]string{"diff", "--name-only", "-M"}, args...)...)
 	out, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("running git diff --name-only %s: %w", diffRange, err)
+		return nil, fmt.Errorf("running git diff --name-only %s: %w", strings.Join(args, " "), err)
 	}
 
 	raw := strings.TrimSpace(string(out))
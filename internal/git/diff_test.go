@@ -0,0 +1,107 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTempRepo creates a git repository with one commit containing the given
+// files and changes the working directory to it. It returns a cleanup
+// function that restores the original working directory.
+func setupTempRepo(t *testing.T, files map[string]string) (string, func()) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	require.NoError(t, os.Chdir(tmpDir))
+
+	runGit(t, "init", "-q")
+	runGit(t, "add", "-A")
+	runGit(t, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	return tmpDir, func() {
+		os.Chdir(origDir)
+	}
+}
+
+// runGit runs a git command in the current directory and fails the test on error.
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
+
+func TestGetTreeDiff_AllFiles(t *testing.T) {
+	_, cleanup := setupTempRepo(t, map[string]string{
+		"domain/user.go": "package domain\n",
+		"infra/db.go":    "package infra\n",
+	})
+	defer cleanup()
+
+	result, err := GetTreeDiff("HEAD", nil)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"domain/user.go", "infra/db.go"}, result.ChangedFiles)
+	assert.Contains(t, result.DiffContent, "new file mode")
+	assert.Contains(t, result.DiffContent, "+package domain")
+	assert.Contains(t, result.DiffContent, "+package infra")
+}
+
+func TestGetTreeDiff_Globs(t *testing.T) {
+	_, cleanup := setupTempRepo(t, map[string]string{
+		"domain/user.go":        "package domain\n",
+		"domain/model/price.go": "package model\n",
+		"infra/db.go":           "package infra\n",
+	})
+	defer cleanup()
+
+	result, err := GetTreeDiff("HEAD", []string{"domain/**"})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"domain/user.go", "domain/model/price.go"}, result.ChangedFiles)
+	assert.NotContains(t, result.DiffContent, "infra/db.go")
+}
+
+func TestGetStagedAndWorktreeDiff(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{
+		"staged.go":   "package main\n",
+		"unstaged.go": "package main\n",
+	})
+	defer cleanup()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "staged.go"), []byte("package main\n\n// staged\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "unstaged.go"), []byte("package main\n\n// unstaged\n"), 0644))
+	runGit(t, "add", "staged.go")
+
+	staged, err := GetStagedDiff()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staged.go"}, staged.ChangedFiles)
+	assert.Contains(t, staged.DiffContent, "+// staged")
+	assert.NotContains(t, staged.DiffContent, "+// unstaged")
+
+	worktree, err := GetWorktreeDiff()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"staged.go", "unstaged.go"}, worktree.ChangedFiles)
+	assert.Contains(t, worktree.DiffContent, "+// unstaged")
+
+	content, err := ShowFile("", "staged.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "// staged")
+}
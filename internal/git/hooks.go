@@ -18,9 +18,64 @@ nohup guardian inbox --notify --since-last-check --quiet &>/dev/null &
 `, HookMarker)
 }
 
-// hookNames lists the git hooks that guardian manages.
+// preCommitScript returns the content for the guardian-managed pre-commit
+// hook, which checks the staged changes and blocks the commit on errors.
+func preCommitScript() string {
+	return fmt.Sprintf(`#!/bin/sh
+%s
+exec guardian check --staged
+`, HookMarker)
+}
+
+// prePushScript returns the content for the guardian-managed pre-push hook.
+// For each pushed ref it checks the commits the remote does not have yet and
+// blocks the push on errors. A new branch, or one whose remote tip is not in
+// the local repository, is checked from its merge base with the remote's
+// default branch (origin/main if that is unknown).
+func prePushScript() string {
+	return fmt.Sprintf(`#!/bin/sh
+%s
+base=$(git symbolic-ref -q --short "refs/remotes/$1/HEAD") || base="$1/main"
+git rev-parse -q --verify "$base^{commit}" >/dev/null || base=origin/main
+while read local_ref local_sha remote_ref remote_sha; do
+	case "$local_sha" in
+	*[!0]*) ;;
+	*) continue ;; # branch deletion
+	esac
+	case "$remote_sha" in
+	*[!0]*) git cat-file -e "$remote_sha^{commit}" 2>/dev/null || remote_sha= ;;
+	*) remote_sha= ;;
+	esac
+	if [ -n "$remote_sha" ]; then
+		guardian check "$remote_sha..$local_sha" || exit 1
+	else
+		guardian check "$base...$local_sha" || exit 1
+	fi
+done
+exit 0
+`, HookMarker)
+}
+
+// hookNames lists the git hooks that guardian always manages.
 var hookNames = []string{"post-merge", "post-checkout"}
 
+// CheckHookNames lists the optional git hooks that run guardian check.
+var CheckHookNames = []string{"pre-commit", "pre-push"}
+
+// hookScriptFor returns the script for the named guardian-managed hook.
+func hookScriptFor(name string) (string, error) {
+	switch name {
+	case "post-merge", "post-checkout":
+		return hookScript(), nil
+	case "pre-commit":
+		return preCommitScript(), nil
+	case "pre-push":
+		return prePushScript(), nil
+	default:
+		return "", fmt.Errorf("unknown hook %q", name)
+	}
+}
+
 // getHooksDir returns the path to the .git/hooks directory by looking for
 // the .git directory starting from the current working directory.
 func getHooksDir() (string, error) {
@@ -44,19 +99,29 @@ func getHooksDir() (string, error) {
 }
 
 // InstallHooks creates post-merge and post-checkout hooks that run
-// guardian inbox in the background. Existing hooks that are not
+// guardian inbox in the background, plus any of the check hooks named in
+// checkHooks ("pre-commit", "pre-push"). Existing hooks that are not
 // guardian-managed are left untouched and an error is returned.
-func InstallHooks() error {
+func InstallHooks(checkHooks ...string) error {
+	for _, name := range checkHooks {
+		if !isCheckHook(name) {
+			return fmt.Errorf("unknown check hook %q (want pre-commit or pre-push)", name)
+		}
+	}
+
 	hooksDir, err := getHooksDir()
 	if err != nil {
 		return err
 	}
 
-	script := hookScript()
-
-	for _, name := range hookNames {
+	for _, name := range append(append([]string{}, hookNames...), checkHooks...) {
 		hookPath := filepath.Join(hooksDir, name)
 
+		script, err := hookScriptFor(name)
+		if err != nil {
+			return err
+		}
+
 		// Check if a non-guardian hook already exists.
 		if data, err := os.ReadFile(hookPath); err == nil {
 			if !strings.Contains(string(data), HookMarker) {
@@ -80,7 +145,7 @@ func UninstallHooks() error {
 		return err
 	}
 
-	for _, name := range hookNames {
+	for _, name := range append(append([]string{}, hookNames...), CheckHookNames...) {
 		hookPath := filepath.Join(hooksDir, name)
 
 		data, err := os.ReadFile(hookPath)
@@ -101,6 +166,16 @@ func UninstallHooks() error {
 	return nil
 }
 
+// isCheckHook reports whether name is one of CheckHookNames.
+func isCheckHook(name string) bool {
+	for _, n := range CheckHookNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // IsHookInstalled checks if a guardian-managed hook exists for the given hook name.
 func IsHookInstalled(hookName string) (bool, error) {
 	hooksDir, err := getHooksDir()
@@ -2,7 +2,9 @@ package git
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -183,3 +185,110 @@ func TestInstallHooks_NoGitDirectory(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not a git repository")
 }
+
+func TestInstallHooks_CheckHooks(t *testing.T) {
+	tmpDir, cleanup := setupTempGitDir(t)
+	defer cleanup()
+
+	require.NoError(t, InstallHooks("pre-commit", "pre-push"))
+
+	hooksDir := filepath.Join(tmpDir, ".git", "hooks")
+
+	preCommit, err := os.ReadFile(filepath.Join(hooksDir, "pre-commit"))
+	require.NoError(t, err)
+	assert.Contains(t, string(preCommit), HookMarker)
+	assert.Contains(t, string(preCommit), "guardian check --staged")
+
+	prePush, err := os.ReadFile(filepath.Join(hooksDir, "pre-push"))
+	require.NoError(t, err)
+	assert.Contains(t, string(prePush), HookMarker)
+	assert.Contains(t, string(prePush), `guardian check "$remote_sha..$local_sha"`)
+
+	info, err := os.Stat(filepath.Join(hooksDir, "pre-commit"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "pre-commit should be executable")
+
+	// Inbox hooks are installed alongside.
+	installed, err := IsHookInstalled("post-merge")
+	require.NoError(t, err)
+	assert.True(t, installed)
+}
+
+func TestPrePushScript_Ranges(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{"README.md": "# test\n"})
+	defer cleanup()
+
+	// A fake guardian records the ranges it is asked to check.
+	bin := t.TempDir()
+	log := filepath.Join(bin, "ranges")
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "guardian"),
+		[]byte("#!/bin/sh\necho \"$2\" >> "+log+"\n"), 0755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	revParse := func(rev string) string {
+		out, err := exec.Command("git", "rev-parse", rev).Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(out))
+	}
+
+	runGit(t, "update-ref", "refs/remotes/origin/main", "HEAD")
+	runGit(t, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	base := revParse("HEAD")
+	commit(t, "change")
+	head := revParse("HEAD")
+	zero := strings.Repeat("0", 40)
+	unknown := strings.Repeat("ab", 20)
+
+	hook := filepath.Join(tmpDir, "pre-push")
+	require.NoError(t, os.WriteFile(hook, []byte(prePushScript()), 0755))
+	cmd := exec.Command(hook, "origin", "git@example.com:repo.git")
+	cmd.Stdin = strings.NewReader(strings.Join([]string{
+		"refs/heads/main " + head + " refs/heads/main " + base,
+		"refs/heads/topic " + head + " refs/heads/topic " + zero,
+		"refs/heads/stale " + head + " refs/heads/stale " + unknown,
+		"(delete) " + zero + " refs/heads/old " + base,
+	}, "\n") + "\n")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	ranges, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		base + ".." + head,
+		"origin/main..." + head,
+		"origin/main..." + head,
+	}, strings.Fields(string(ranges)))
+}
+
+func TestInstallHooks_CheckHooksOptional(t *testing.T) {
+	tmpDir, cleanup := setupTempGitDir(t)
+	defer cleanup()
+
+	require.NoError(t, InstallHooks())
+
+	_, err := os.Stat(filepath.Join(tmpDir, ".git", "hooks", "pre-commit"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestInstallHooks_UnknownCheckHook(t *testing.T) {
+	_, cleanup := setupTempGitDir(t)
+	defer cleanup()
+
+	err := InstallHooks("post-receive")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown check hook")
+}
+
+func TestUninstallHooks_RemovesCheckHooks(t *testing.T) {
+	tmpDir, cleanup := setupTempGitDir(t)
+	defer cleanup()
+
+	require.NoError(t, InstallHooks("pre-commit", "pre-push"))
+	require.NoError(t, UninstallHooks())
+
+	hooksDir := filepath.Join(tmpDir, ".git", "hooks")
+	for _, name := range []string{"pre-commit", "pre-push", "post-merge"} {
+		_, err := os.Stat(filepath.Join(hooksDir, name))
+		assert.True(t, os.IsNotExist(err), "%s should be removed", name)
+	}
+}
@@ -0,0 +1,178 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Commit is a commit listed by ListCommits.
+type Commit struct {
+	SHA            string
+	Parents        []string
+	AuthorEmail    string
+	CommitterEmail string
+	// SignatureStatus is git's verdict on the commit's GPG or SSH
+	// signature (%G?): "G" good, "U" good with unknown validity, "N" none,
+	// "B" bad, "X" expired signature, "Y" expired key, "R" revoked key, or
+	// "E" cannot be checked (e.g., the key or verifier is missing). It is
+	// empty unless the commit was listed withSignatures.
+	SignatureStatus string
+	// SigningKey, SigningFingerprint and PrimaryFingerprint identify the key
+	// that signed the commit (%GK, %GF and %GP); unset parts are empty.
+	SigningKey         string
+	SigningFingerprint string
+	PrimaryFingerprint string
+	// Subject is the first paragraph of the message joined into one line.
+	Subject string
+	// Body is the rest of the message, trailers included.
+	Body string
+	// Trailers are the trailers at the end of the message (e.g.,
+	// "Signed-off-by"), in order, with continuation lines unfolded.
+	Trailers []Trailer
+}
+
+// Trailer is a "Key: value" line of a commit message's trailer block.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// IsMerge reports whether the commit has more than one parent.
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// commitFormat returns the format of the fields read by parseCommits,
+// separated by NULs; with -z, git also ends each commit with a NUL. The
+// signature fields make git verify every commit's signature, so they are
+// only requested withSignatures.
+func commitFormat(withSignatures bool) string {
+	fields := []string{"%H", "%P", "%ae", "%ce"}
+	if withSignatures {
+		fields = append(fields, "%G?", "%GK", "%GF", "%GP")
+	}
+	fields = append(fields, "%s", "%b", "%(trailers:only,unfold)")
+	return strings.Join(fields, "%x00")
+}
+
+// ListCommits returns the commits in the given revision range, oldest first.
+// The range has the same form as for git log, e.g., "base..head" for the
+// commits reachable from head but not from base. If paths are given, only
+// commits that touch one of them are listed. Signatures are verified, and
+// the signature fields of the commits set, only if withSignatures is true.
+func ListCommits(revRange string, withSignatures bool, paths ...string) ([]Commit, error) {
+	args := append([]string{"log", "-z", "--reverse", "--format=" + commitFormat(withSignatures), revRange, "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git log %s: %w", revRange, err)
+	}
+	return parseCommits(string(out), withSignatures)
+}
+
+// parseCommits parses the output of git log -z --format=commitFormat(withSignatures).
+func parseCommits(out string, withSignatures bool) ([]Commit, error) {
+	if out == "" {
+		return nil, nil
+	}
+
+	n := 7
+	if withSignatures {
+		n = 11
+	}
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	if len(fields)%n != 0 {
+		return nil, fmt.Errorf("parsing git log output: unexpected number of fields (%d)", len(fields))
+	}
+
+	var commits []Commit
+	for i := 0; i < len(fields); i += n {
+		f := fields[i : i+n]
+		c := Commit{
+			SHA:            f[0],
+			Parents:        strings.Fields(f[1]),
+			AuthorEmail:    f[2],
+			CommitterEmail: f[3],
+		}
+		if withSignatures {
+			c.SignatureStatus = f[4]
+			c.SigningKey = f[5]
+			c.SigningFingerprint = f[6]
+			c.PrimaryFingerprint = f[7]
+			f = f[4:]
+		}
+		c.Subject = f[4]
+		c.Body = strings.TrimRight(f[5], "\n")
+		c.Trailers = parseTrailers(f[6])
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// ParseCommitMessage splits a commit message into the Subject, Body and
+// Trailers of a Commit as ListCommits would report them; the other fields
+// are left empty. Trailers are read from the last paragraph of the body if
+// every line of it is a "Key: value" line or the continuation of one.
+func ParseCommitMessage(message string) Commit {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	n := 0
+	for n < len(lines) && strings.TrimSpace(lines[n]) != "" {
+		n++
+	}
+	subject := make([]string, 0, n)
+	for _, line := range lines[:n] {
+		subject = append(subject, strings.TrimSpace(line))
+	}
+
+	commit := Commit{
+		Subject: strings.Join(subject, " "),
+		Body:    strings.Trim(strings.Join(lines[n:], "\n"), "\n"),
+	}
+	if commit.Body != "" {
+		last := commit.Body
+		if i := strings.LastIndex(last, "\n\n"); i >= 0 {
+			last = last[i+2:]
+		}
+		commit.Trailers = parseTrailerBlock(last)
+	}
+	return commit
+}
+
+// parseTrailerBlock parses a paragraph of "Key: value" lines, unfolding
+// continuation lines, or returns nil if the paragraph is not a trailer block.
+func parseTrailerBlock(paragraph string) []Trailer {
+	var trailers []Trailer
+	for _, line := range strings.Split(paragraph, "\n") {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if len(trailers) == 0 {
+				return nil
+			}
+			last := &trailers[len(trailers)-1]
+			last.Value = strings.TrimSpace(last.Value + " " + strings.TrimSpace(line))
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil
+		}
+		trailers = append(trailers, Trailer{Key: key, Value: strings.TrimSpace(value)})
+	}
+	return trailers
+}
+
+// parseTrailers parses the "Key: value" lines printed by %(trailers).
+func parseTrailers(s string) []Trailer {
+	var trailers []Trailer
+	for _, line := range strings.Split(s, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		trailers = append(trailers, Trailer{
+			Key:   strings.TrimSpace(key),
+			Value: strings.TrimSpace(value),
+		})
+	}
+	return trailers
+}
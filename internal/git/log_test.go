@@ -0,0 +1,135 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commit records an empty commit with the given message.
+func commit(t *testing.T, message string) {
+	t.Helper()
+	runGit(t, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", message)
+}
+
+func TestListCommits(t *testing.T) {
+	_, cleanup := setupTempRepo(t, map[string]string{"README.md": "# test\n"})
+	defer cleanup()
+
+	runGit(t, "tag", "base")
+	commit(t, "feat(api): add orders\nendpoint\n\nLonger explanation.\n\nRefs: SHOP-12\nSigned-off-by: Test\n <test@example.com>\n")
+	commit(t, "WIP")
+
+	commits, err := ListCommits("base..HEAD", false)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	first := commits[0]
+	assert.Len(t, first.SHA, 40)
+	assert.Len(t, first.Parents, 1)
+	assert.False(t, first.IsMerge())
+	assert.Equal(t, "test@example.com", first.AuthorEmail)
+	assert.Equal(t, "test@example.com", first.CommitterEmail)
+	assert.Empty(t, first.SignatureStatus)
+	assert.Empty(t, first.SigningKey)
+	assert.Equal(t, "feat(api): add orders endpoint", first.Subject)
+	assert.Equal(t, "Longer explanation.\n\nRefs: SHOP-12\nSigned-off-by: Test\n <test@example.com>", first.Body)
+	assert.Equal(t, []Trailer{
+		{Key: "Refs", Value: "SHOP-12"},
+		{Key: "Signed-off-by", Value: "Test <test@example.com>"},
+	}, first.Trailers)
+
+	assert.Equal(t, "WIP", commits[1].Subject)
+	assert.Empty(t, commits[1].Body)
+	assert.Empty(t, commits[1].Trailers)
+
+	commits, err = ListCommits("base..HEAD", true)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "N", commits[0].SignatureStatus)
+	assert.Empty(t, commits[0].SigningKey)
+	assert.Equal(t, first.Subject, commits[0].Subject)
+	assert.Equal(t, first.Trailers, commits[0].Trailers)
+
+	commits, err = ListCommits("HEAD..HEAD", false)
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+
+	_, err = ListCommits("missing..HEAD", false)
+	assert.Error(t, err)
+}
+
+func TestListCommits_Paths(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{"README.md": "# test\n"})
+	defer cleanup()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "votes"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "votes", "a.yml"), []byte("decision: yes\n"), 0644))
+	runGit(t, "add", "-A")
+	commit(t, "vote")
+	commit(t, "unrelated")
+
+	commits, err := ListCommits("HEAD", false, "votes", "missing.yml")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "vote", commits[0].Subject)
+}
+
+func TestListCommits_SSHSignature(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not installed")
+	}
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{"README.md": "# test\n"})
+	defer cleanup()
+
+	key := filepath.Join(t.TempDir(), "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test@example.com", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(out))
+	pub, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+	signers := filepath.Join(tmpDir, ".git", "allowed_signers")
+	require.NoError(t, os.WriteFile(signers, []byte("test@example.com "+string(pub)), 0644))
+
+	runGit(t, "config", "gpg.format", "ssh")
+	runGit(t, "config", "user.signingkey", key)
+	runGit(t, "config", "gpg.ssh.allowedSignersFile", signers)
+	runGit(t, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-S", "-m", "signed")
+
+	commits, err := ListCommits("HEAD~1..HEAD", true)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "G", commits[0].SignatureStatus)
+	assert.True(t, strings.HasPrefix(commits[0].SigningKey, "SHA256:"), commits[0].SigningKey)
+	assert.Equal(t, commits[0].SigningKey, commits[0].SigningFingerprint)
+}
+
+func TestParseCommitMessage(t *testing.T) {
+	// The same message as in TestListCommits, parsed without git.
+	c := ParseCommitMessage("feat(api): add orders\nendpoint\n\nLonger explanation.\n\nRefs: SHOP-12\nSigned-off-by: Test\n <test@example.com>\n")
+	assert.Equal(t, "feat(api): add orders endpoint", c.Subject)
+	assert.Equal(t, "Longer explanation.\n\nRefs: SHOP-12\nSigned-off-by: Test\n <test@example.com>", c.Body)
+	assert.Equal(t, []Trailer{
+		{Key: "Refs", Value: "SHOP-12"},
+		{Key: "Signed-off-by", Value: "Test <test@example.com>"},
+	}, c.Trailers)
+
+	c = ParseCommitMessage("WIP")
+	assert.Equal(t, "WIP", c.Subject)
+	assert.Empty(t, c.Body)
+	assert.Empty(t, c.Trailers)
+
+	// A last paragraph that is not all trailers has none.
+	c = ParseCommitMessage("fix: totals\n\nRefs: SHOP-1\nand a sentence")
+	assert.Empty(t, c.Trailers)
+}
+
+func TestParseCommits_Malformed(t *testing.T) {
+	_, err := parseCommits("abc\x00\x00subject\x00", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected number of fields")
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileSource returns the content of a repository file, identified by its
+// slash-separated path relative to the repository root.
+type FileSource func(path string) ([]byte, error)
+
+// AtRevision returns a FileSource that reads files as of the given revision
+// using git show. An empty rev reads the staged version from the index.
+func AtRevision(rev string) FileSource {
+	return func(path string) ([]byte, error) {
+		return ShowFile(rev, path)
+	}
+}
+
+// FromWorktree returns a FileSource that reads files from the working tree
+// rooted at root.
+func FromWorktree(root string) FileSource {
+	return func(path string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	}
+}
+
+// Unavailable returns a FileSource that always fails with the given reason,
+// e.g., for the base side of a whole-repository scan.
+func Unavailable(reason string) FileSource {
+	return func(path string) ([]byte, error) {
+		return nil, fmt.Errorf("%s: %s", path, reason)
+	}
+}
+
+// RepoReader reads file contents at the base and head sides of a diff. Each
+// file is read at most once per side; results, including errors, are cached.
+// It is safe for concurrent use.
+type RepoReader struct {
+	base FileSource
+	head FileSource
+
+	mu    sync.Mutex
+	cache map[repoKey]*repoEntry
+}
+
+type repoKey struct {
+	head bool
+	path string
+}
+
+type repoEntry struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// NewRepoReader creates a RepoReader that reads the base side of a diff from
+// base and the head side from head.
+func NewRepoReader(base, head FileSource) *RepoReader {
+	return &RepoReader{
+		base:  base,
+		head:  head,
+		cache: make(map[repoKey]*repoEntry),
+	}
+}
+
+// ReadBase returns the content of path before the change.
+func (r *RepoReader) ReadBase(path string) ([]byte, error) {
+	return r.read(repoKey{head: false, path: path}, r.base)
+}
+
+// ReadHead returns the content of path after the change.
+func (r *RepoReader) ReadHead(path string) ([]byte, error) {
+	return r.read(repoKey{head: true, path: path}, r.head)
+}
+
+func (r *RepoReader) read(key repoKey, src FileSource) ([]byte, error) {
+	r.mu.Lock()
+	entry, ok := r.cache[key]
+	if !ok {
+		entry = &repoEntry{}
+		r.cache[key] = entry
+	}
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.data, entry.err = src(key.path)
+	})
+	return entry.data, entry.err
+}
+
+// MergeBase returns the best common ancestor of two revisions, as used by a
+// "base...head" diff range.
+func MergeBase(a, b string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, b)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running git merge-base %s %s: %w", a, b, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
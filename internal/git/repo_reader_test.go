@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoReader_ReadsBothSides(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{
+		"src/app.go": "package src // v1\n",
+	})
+	defer cleanup()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "src", "app.go"), []byte("package src // v2\n"), 0644))
+
+	r := NewRepoReader(AtRevision("HEAD"), FromWorktree(tmpDir))
+
+	base, err := r.ReadBase("src/app.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package src // v1\n", string(base))
+
+	head, err := r.ReadHead("src/app.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package src // v2\n", string(head))
+
+	_, err = r.ReadBase("missing.go")
+	assert.Error(t, err)
+}
+
+func TestRepoReader_CachesReads(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	src := func(path string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[path]++
+		if path == "missing" {
+			return nil, fmt.Errorf("not found")
+		}
+		return []byte("content of " + path), nil
+	}
+
+	r := NewRepoReader(src, src)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := r.ReadHead("a.go")
+			assert.NoError(t, err)
+			assert.Equal(t, "content of a.go", string(data))
+			_, err = r.ReadHead("missing")
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+
+	_, err := r.ReadBase("a.go")
+	require.NoError(t, err)
+
+	// One read per path for the head side, plus one for the base side.
+	assert.Equal(t, 2, calls["a.go"])
+	assert.Equal(t, 1, calls["missing"])
+}
+
+func TestUnavailable(t *testing.T) {
+	_, err := Unavailable("no base revision")("a.go")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a.go: no base revision")
+}
+
+func TestMergeBase(t *testing.T) {
+	_, cleanup := setupTempRepo(t, map[string]string{"a.txt": "a\n"})
+	defer cleanup()
+
+	base, err := MergeBase("HEAD", "HEAD")
+	require.NoError(t, err)
+	assert.Len(t, base, 40)
+
+	_, err = MergeBase("HEAD", "no-such-branch")
+	assert.Error(t, err)
+}
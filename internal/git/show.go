@@ -0,0 +1,29 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// ShowFile returns the content of the file at path as of the given revision by
+// running git show <rev>:<path>. The path is relative to the repository root.
+// An empty rev reads the staged version of the file from the index.
+func ShowFile(rev, path string) ([]byte, error) {
+	cmd := exec.Command("git", "show", rev+":"+path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git show %s:%s: %w", rev, path, err)
+	}
+	return out, nil
+}
+
+// ShowFileIn is like ShowFile but reads from the repository at repoDir
+// instead of the current one.
+func ShowFileIn(repoDir, rev, path string) ([]byte, error) {
+	cmd := exec.Command("git", "-C", repoDir, "show", rev+":"+path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git show %s:%s in %s: %w", rev, path, repoDir, err)
+	}
+	return out, nil
+}
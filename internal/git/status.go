@@ -0,0 +1,61 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DirtyFiles returns the files among paths that have uncommitted changes in
+// the repository at repoDir: staged or unstaged modifications, or untracked
+// files. Paths are slash-separated and relative to the repository root.
+func DirtyFiles(repoDir string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"-C", repoDir, "status", "--porcelain", "-z", "--untracked-files=all", "--"}, paths...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git status: %w", err)
+	}
+
+	// Each entry is "XY path"; renames and copies are followed by a
+	// separate entry holding the original path.
+	var dirty []string
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		dirty = append(dirty, entry[3:])
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++ // skip the original path
+		}
+	}
+	return dirty, nil
+}
+
+// UnstagedFiles returns the files among paths whose working-tree content
+// differs from the index in the repository at repoDir. Paths are
+// slash-separated and relative to the repository root.
+func UnstagedFiles(repoDir string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"-C", repoDir, "diff", "--name-only", "-z", "--"}, paths...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git diff: %w", err)
+	}
+
+	var unstaged []string
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" {
+			unstaged = append(unstaged, path)
+		}
+	}
+	return unstaged, nil
+}
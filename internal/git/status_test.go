@@ -0,0 +1,61 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirtyFiles(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{
+		"clean.go":    "package a\n",
+		"modified.go": "package a\n",
+		"staged.go":   "package a\n",
+		"old.go":      "package a\n",
+	})
+	defer cleanup()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "modified.go"), []byte("package b\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "staged.go"), []byte("package b\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "new"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "new", "untracked.go"), []byte("package a\n"), 0644))
+	runGit(t, "add", "staged.go")
+	runGit(t, "mv", "old.go", "renamed.go")
+
+	dirty, err := DirtyFiles(tmpDir, []string{"clean.go", "modified.go", "staged.go", "new/untracked.go", "renamed.go", "old.go"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"modified.go", "staged.go", "new/untracked.go", "renamed.go"}, dirty)
+
+	dirty, err = DirtyFiles(tmpDir, []string{"clean.go"})
+	require.NoError(t, err)
+	assert.Empty(t, dirty)
+
+	dirty, err = DirtyFiles(tmpDir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, dirty)
+}
+
+func TestUnstagedFiles(t *testing.T) {
+	tmpDir, cleanup := setupTempRepo(t, map[string]string{
+		"clean.go":    "package a\n",
+		"staged.go":   "package a\n",
+		"modified.go": "package a\n",
+	})
+	defer cleanup()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "staged.go"), []byte("package b\n"), 0644))
+	runGit(t, "add", "staged.go")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "modified.go"), []byte("package b\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "untracked.go"), []byte("package a\n"), 0644))
+
+	unstaged, err := UnstagedFiles(tmpDir, []string{"clean.go", "staged.go", "modified.go", "untracked.go"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"modified.go"}, unstaged)
+
+	unstaged, err = UnstagedFiles(tmpDir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, unstaged)
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -33,6 +34,7 @@ type Violation struct {
 	Severity    string
 	Description string
 	FilePath    string
+	Line        int
 	DiffSnippet string
 }
 
@@ -138,7 +140,8 @@ func NewClient(cfg config.LLMConfig) (*Client, error) {
 // AnalyzeCheck sends diff content and violations to the LLM for analysis.
 // It returns explanations keyed by rule ID.
 // If proposals is non-nil, governance context is included in the prompt.
-func (c *Client) AnalyzeCheck(diffContent string, rules []config.Rule, violations []Violation, proposals []*config.Proposal) (*CheckAnalysis, error) {
+// Canceling ctx aborts the request.
+func (c *Client) AnalyzeCheck(ctx context.Context, diffContent string, rules []config.Rule, violations []Violation, proposals []*config.Proposal) (*CheckAnalysis, error) {
 	systemPrompt := GetCheckPrompt(c.prompts.CheckSystem)
 
 	var userContent strings.Builder
@@ -148,7 +151,11 @@ func (c *Client) AnalyzeCheck(diffContent string, rules []config.Rule, violation
 
 	userContent.WriteString("## Team Rules\n")
 	for _, r := range rules {
+		if !r.IsEnabled() {
+			continue
+		}
 		fmt.Fprintf(&userContent, "- **%s** (%s): %s\n", r.ID, r.Severity, r.Description)
+		writeRuleMetadata(&userContent, r)
 	}
 	userContent.WriteString("\n")
 
@@ -156,7 +163,11 @@ func (c *Client) AnalyzeCheck(diffContent string, rules []config.Rule, violation
 	for _, v := range violations {
 		fmt.Fprintf(&userContent, "### %s [%s]\n", v.RuleID, v.Severity)
 		fmt.Fprintf(&userContent, "- Description: %s\n", v.Description)
-		fmt.Fprintf(&userContent, "- File: %s\n", v.FilePath)
+		if v.Line > 0 {
+			fmt.Fprintf(&userContent, "- File: %s:%d\n", v.FilePath, v.Line)
+		} else {
+			fmt.Fprintf(&userContent, "- File: %s\n", v.FilePath)
+		}
 		if v.DiffSnippet != "" {
 			fmt.Fprintf(&userContent, "- Diff snippet:\n```\n%s\n```\n", v.DiffSnippet)
 		}
@@ -199,7 +210,7 @@ func (c *Client) AnalyzeCheck(diffContent string, rules []config.Rule, violation
 	userContent.WriteString("Please provide a brief explanation for each violation, keyed by rule_id. ")
 	userContent.WriteString("Format your response as one paragraph per violation, starting each with the rule_id in brackets like [rule_id].")
 
-	responseText, err := c.sendMessage(systemPrompt, userContent.String())
+	responseText, err := c.sendMessage(ctx, systemPrompt, userContent.String())
 	if err != nil {
 		return nil, fmt.Errorf("LLM analysis failed: %w", err)
 	}
@@ -208,16 +219,34 @@ func (c *Client) AnalyzeCheck(diffContent string, rules []config.Rule, violation
 	return &CheckAnalysis{Explanations: explanations}, nil
 }
 
+// writeRuleMetadata writes the optional metadata of a rule as nested list
+// items, so the LLM can explain why the rule exists and whom to ask.
+func writeRuleMetadata(b *strings.Builder, r config.Rule) {
+	if r.Rationale != "" {
+		fmt.Fprintf(b, "  - Rationale: %s\n", r.Rationale)
+	}
+	if r.Owner != "" {
+		fmt.Fprintf(b, "  - Owner: %s\n", r.Owner)
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(b, "  - Tags: %s\n", strings.Join(r.Tags, ", "))
+	}
+	if r.DocsURL != "" {
+		fmt.Fprintf(b, "  - Docs: %s\n", r.DocsURL)
+	}
+}
+
 // DraftProposal generates a proposal draft using the LLM.
-func (c *Client) DraftProposal(rule config.Rule, context string) (*ProposalDraft, error) {
+// Canceling ctx aborts the request.
+func (c *Client) DraftProposal(ctx context.Context, rule config.Rule, llmContext string) (*ProposalDraft, error) {
 	systemPrompt := GetProposePrompt(c.prompts.ProposeSystem)
 
 	var userContent strings.Builder
 	fmt.Fprintf(&userContent, "## Rule\n- ID: %s\n- Description: %s\n- Type: %s\n- Severity: %s\n\n",
 		rule.ID, rule.Description, rule.Type, rule.Severity)
 
-	if context != "" {
-		fmt.Fprintf(&userContent, "## Context\n%s\n\n", context)
+	if llmContext != "" {
+		fmt.Fprintf(&userContent, "## Context\n%s\n\n", llmContext)
 	}
 
 	userContent.WriteString("Please generate a proposal with the following sections:\n")
@@ -226,7 +255,7 @@ func (c *Client) DraftProposal(rule config.Rule, context string) (*ProposalDraft
 	userContent.WriteString("REASON: (why this change is needed)\n")
 	userContent.WriteString("IMPACT: (expected impact)\n")
 
-	responseText, err := c.sendMessage(systemPrompt, userContent.String())
+	responseText, err := c.sendMessage(ctx, systemPrompt, userContent.String())
 	if err != nil {
 		return nil, fmt.Errorf("LLM proposal draft failed: %w", err)
 	}
@@ -236,16 +265,16 @@ func (c *Client) DraftProposal(rule config.Rule, context string) (*ProposalDraft
 }
 
 // sendMessage sends a message to the configured LLM provider and returns the response text.
-func (c *Client) sendMessage(systemPrompt, userMessage string) (string, error) {
+func (c *Client) sendMessage(ctx context.Context, systemPrompt, userMessage string) (string, error) {
 	if c.provider == ProviderClaude {
-		return c.sendClaudeMessage(systemPrompt, userMessage)
+		return c.sendClaudeMessage(ctx, systemPrompt, userMessage)
 	}
-	return c.sendOpenAIMessage(systemPrompt, userMessage)
+	return c.sendOpenAIMessage(ctx, systemPrompt, userMessage)
 }
 
 // sendOpenAIMessage sends a message using the OpenAI-compatible API format.
 // This works for deepseek, openai, and custom providers.
-func (c *Client) sendOpenAIMessage(systemPrompt, userMessage string) (string, error) {
+func (c *Client) sendOpenAIMessage(ctx context.Context, systemPrompt, userMessage string) (string, error) {
 	reqBody := openAIRequest{
 		Model: c.model,
 		Messages: []openAIMessage{
@@ -260,7 +289,7 @@ func (c *Client) sendOpenAIMessage(systemPrompt, userMessage string) (string, er
 	}
 
 	url := strings.TrimRight(c.endpoint, "/") + "/chat/completions"
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("creating request: %w", err)
 	}
@@ -300,7 +329,7 @@ func (c *Client) sendOpenAIMessage(systemPrompt, userMessage string) (string, er
 }
 
 // sendClaudeMessage sends a message using the Anthropic Messages API format.
-func (c *Client) sendClaudeMessage(systemPrompt, userMessage string) (string, error) {
+func (c *Client) sendClaudeMessage(ctx context.Context, systemPrompt, userMessage string) (string, error) {
 	reqBody := claudeRequest{
 		Model:     c.model,
 		MaxTokens: 4096,
@@ -316,7 +345,7 @@ func (c *Client) sendClaudeMessage(systemPrompt, userMessage string) (string, er
 	}
 
 	url := strings.TrimRight(c.endpoint, "/") + "/messages"
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("creating request: %w", err)
 	}
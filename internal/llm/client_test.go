@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -185,7 +186,7 @@ func TestAnalyzeCheck_OpenAI(t *testing.T) {
 		},
 	}
 
-	analysis, err := client.AnalyzeCheck("diff content", rules, violations, nil)
+	analysis, err := client.AnalyzeCheck(context.Background(), "diff content", rules, violations, nil)
 	require.NoError(t, err)
 	require.NotNil(t, analysis)
 	assert.Contains(t, analysis.Explanations, "test_rule")
@@ -257,7 +258,7 @@ func TestAnalyzeCheck_Claude(t *testing.T) {
 		},
 	}
 
-	analysis, err := client.AnalyzeCheck("diff", rules, violations, nil)
+	analysis, err := client.AnalyzeCheck(context.Background(), "diff", rules, violations, nil)
 	require.NoError(t, err)
 	require.NotNil(t, analysis)
 	assert.Contains(t, analysis.Explanations, "claude_rule")
@@ -305,7 +306,7 @@ IMPACT: Files in domain/adapters/ can now import from infra/ package`,
 		Severity:    "error",
 	}
 
-	draft, err := client.DraftProposal(rule, "We need adapters to access infra")
+	draft, err := client.DraftProposal(context.Background(), rule, "We need adapters to access infra")
 	require.NoError(t, err)
 	require.NotNil(t, draft)
 	assert.Contains(t, draft.ChangeDescription, "infra imports")
@@ -352,7 +353,7 @@ IMPACT: Presentation layer files can use float types`,
 		Severity:    "warning",
 	}
 
-	draft, err := client.DraftProposal(rule, "")
+	draft, err := client.DraftProposal(context.Background(), rule, "")
 	require.NoError(t, err)
 	require.NotNil(t, draft)
 	assert.NotEmpty(t, draft.ChangeDescription)
@@ -376,7 +377,7 @@ func TestAnalyzeCheck_ServerError(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "status 500")
 }
@@ -405,7 +406,7 @@ func TestAnalyzeCheck_APIError(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "Rate limit exceeded")
 }
@@ -432,7 +433,7 @@ func TestAnalyzeCheck_EmptyChoices(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no choices")
 }
@@ -457,11 +458,42 @@ func TestAnalyzeCheck_Timeout(t *testing.T) {
 	// Override the timeout to be short for testing
 	client.httpClient.Timeout = 100 * time.Millisecond
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "sending request")
 }
 
+func TestAnalyzeCheck_Canceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Setenv("GUARDIAN_LLM_API_KEY", "test-key")
+
+	cfg := config.LLMConfig{
+		Provider: ProviderClaude,
+		Endpoint: server.URL + "/v1",
+	}
+
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = client.AnalyzeCheck(ctx, "diff", nil, nil, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
 func TestAnalyzeCheck_ClaudeAPIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := claudeResponse{
@@ -486,7 +518,7 @@ func TestAnalyzeCheck_ClaudeAPIError(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "Authentication failed")
 }
@@ -513,7 +545,7 @@ func TestAnalyzeCheck_ClaudeEmptyContent(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no content")
 }
@@ -650,7 +682,56 @@ func TestAnalyzeCheck_InvalidJSON(t *testing.T) {
 	client, err := NewClient(cfg)
 	require.NoError(t, err)
 
-	_, err = client.AnalyzeCheck("diff", nil, nil, nil)
+	_, err = client.AnalyzeCheck(context.Background(), "diff", nil, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "parsing response JSON")
 }
+
+func TestAnalyzeCheck_IncludesRuleMetadata(t *testing.T) {
+	var userContent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		userContent = req.Messages[1].Content
+
+		resp := openAIResponse{
+			Choices: []struct {
+				Message openAIMessage `json:"message"`
+			}{
+				{Message: openAIMessage{Role: "assistant", Content: "[no_secrets] Rotate the key."}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	t.Setenv("GUARDIAN_LLM_API_KEY", "test-api-key")
+	client, err := NewClient(config.LLMConfig{Provider: ProviderOpenAI, Endpoint: server.URL + "/v1", Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	disabled := false
+	rules := []config.Rule{
+		{
+			ID:          "no_secrets",
+			Description: "No hardcoded secrets",
+			Severity:    "error",
+			Owner:       "security",
+			Tags:        []string{"security", "compliance"},
+			DocsURL:     "https://wiki.example.com/adr/12",
+			Rationale:   "Leaked credentials must be rotated.",
+		},
+		{ID: "retired_rule", Description: "Retired", Severity: "warning", Enabled: &disabled},
+	}
+	violations := []Violation{{RuleID: "no_secrets", Severity: "error", FilePath: "app.py"}}
+
+	_, err = client.AnalyzeCheck(context.Background(), "diff content", rules, violations, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, userContent, "- **no_secrets** (error): No hardcoded secrets\n")
+	assert.Contains(t, userContent, "  - Rationale: Leaked credentials must be rotated.\n")
+	assert.Contains(t, userContent, "  - Owner: security\n")
+	assert.Contains(t, userContent, "  - Tags: security, compliance\n")
+	assert.Contains(t, userContent, "  - Docs: https://wiki.example.com/adr/12\n")
+	assert.NotContains(t, userContent, "retired_rule")
+}
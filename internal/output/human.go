@@ -15,6 +15,14 @@ func PrintCheckReportHuman(w io.Writer, r *CheckReport) {
 	if len(r.Violations) == 0 {
 		fmt.Fprintln(w, "No violations found.")
 		fmt.Fprintln(w)
+		printBaselined(w, r.Baselined)
+		printSuppressions(w, r.Suppressions)
+		printRuleErrors(w, r.RuleErrors)
+		printFixes(w, r.Fixes)
+		if !r.Summary.Passed {
+			fmt.Fprintln(w, "Result: FAILED")
+			return
+		}
 		fmt.Fprintln(w, "Result: PASSED")
 		return
 	}
@@ -29,7 +37,11 @@ func PrintCheckReportHuman(w io.Writer, r *CheckReport) {
 		fmt.Fprintf(w, "  %s\n", v.Description)
 
 		if v.FilePath != "" {
-			fmt.Fprintf(w, "  File: %s\n", v.FilePath)
+			fmt.Fprintf(w, "  File: %s\n", formatLocation(v.FilePath, v.Line, v.EndLine))
+		}
+
+		if v.Commit != "" {
+			fmt.Fprintf(w, "  Commit: %s\n", v.Commit)
 		}
 
 		if v.DiffSnippet != "" {
@@ -71,6 +83,11 @@ func PrintCheckReportHuman(w io.Writer, r *CheckReport) {
 		}
 	}
 
+	printBaselined(w, r.Baselined)
+	printSuppressions(w, r.Suppressions)
+	printRuleErrors(w, r.RuleErrors)
+	printFixes(w, r.Fixes)
+
 	passedStr := "PASSED"
 	if !r.Summary.Passed {
 		passedStr = "FAILED"
@@ -80,6 +97,131 @@ func PrintCheckReportHuman(w io.Writer, r *CheckReport) {
 		r.Summary.Errors, r.Summary.Warnings, passedStr)
 }
 
+// printBaselined writes a compact list of violations suppressed by the
+// baseline, one per line. It writes nothing if there are none.
+func printBaselined(w io.Writer, baselined []ViolationReport) {
+	if len(baselined) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Baselined Violations")
+	fmt.Fprintln(w, "--------------------")
+	fmt.Fprintf(w, "%d known violation(s) from the baseline, not counted:\n", len(baselined))
+	for _, v := range baselined {
+		fmt.Fprintf(w, "  [%s] %s %s\n", v.Severity, v.RuleID, formatLocation(v.FilePath, v.Line, v.EndLine))
+	}
+	fmt.Fprintln(w)
+}
+
+// printSuppressions writes the violations matched by inline guardian:ignore
+// comments, one per line, followed by the suppressions the constitution
+// rejected. It writes nothing if there are none.
+func printSuppressions(w io.Writer, suppressions []SuppressionReport) {
+	if len(suppressions) == 0 {
+		return
+	}
+
+	var applied, rejected []SuppressionReport
+	for _, s := range suppressions {
+		if s.Rejected != "" {
+			rejected = append(rejected, s)
+		} else {
+			applied = append(applied, s)
+		}
+	}
+
+	fmt.Fprintln(w, "Inline Suppressions")
+	fmt.Fprintln(w, "-------------------")
+	if len(applied) > 0 {
+		fmt.Fprintf(w, "%d violation(s) suppressed by guardian:ignore, not counted:\n", len(applied))
+		for _, s := range applied {
+			fmt.Fprintf(w, "  [%s] %s %s%s\n", s.Severity, s.RuleID, formatLocation(s.FilePath, s.Line, 0), formatReason(s.Reason))
+		}
+	}
+	if len(rejected) > 0 {
+		fmt.Fprintf(w, "%d suppression(s) not applied:\n", len(rejected))
+		for _, s := range rejected {
+			fmt.Fprintf(w, "  [%s] %s %s: %s\n", s.Severity, s.RuleID, formatLocation(s.FilePath, s.CommentLine, 0), s.Rejected)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// formatReason renders a suppression reason as a suffix, or nothing if the
+// suppression has none.
+func formatReason(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(" - %q", reason)
+}
+
+// printRuleErrors writes the rules that could not be evaluated. It writes
+// nothing if all rules ran.
+func printRuleErrors(w io.Writer, ruleErrors []RuleErrorReport) {
+	if len(ruleErrors) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Rule Errors")
+	fmt.Fprintln(w, "-----------")
+	for _, e := range ruleErrors {
+		fmt.Fprintf(w, "  %s (%s): %s\n", e.RuleID, e.RuleType, e.Error)
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintFixReportHuman writes a human-readable summary of the fixes for a
+// check to the given writer.
+func PrintFixReportHuman(w io.Writer, r *FixReport) {
+	printFixes(w, r)
+}
+
+// printFixes writes the lines changed by rule fixes, with their old and new
+// content, followed by the violations that could not be fixed. It writes
+// nothing if no fixes were attempted.
+func printFixes(w io.Writer, r *FixReport) {
+	if r == nil {
+		return
+	}
+
+	fmt.Fprintln(w, "Fixes")
+	fmt.Fprintln(w, "-----")
+	verb := "Would fix"
+	if r.Applied {
+		verb = "Fixed"
+	}
+	if len(r.Lines) == 0 {
+		fmt.Fprintln(w, "No fixable violations.")
+	} else {
+		fmt.Fprintf(w, "%s %d line(s) in %d file(s):\n", verb, len(r.Lines), r.Files)
+	}
+	for _, l := range r.Lines {
+		fmt.Fprintf(w, "  %s %s\n", l.RuleID, formatLocation(l.FilePath, l.Line, 0))
+		fmt.Fprintf(w, "    - %s\n", l.Old)
+		fmt.Fprintf(w, "    + %s\n", l.New)
+	}
+	if len(r.Skipped) > 0 {
+		fmt.Fprintf(w, "%d violation(s) not fixed:\n", len(r.Skipped))
+		for _, s := range r.Skipped {
+			fmt.Fprintf(w, "  [%s] %s %s: %s\n", s.Severity, s.RuleID, formatLocation(s.FilePath, s.Line, 0), s.Reason)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// formatLocation renders a file path with an optional line range, e.g.
+// "main.go", "main.go:12" or "main.go:12-14".
+func formatLocation(path string, line, endLine int) string {
+	if line <= 0 {
+		return path
+	}
+	if endLine > line {
+		return fmt.Sprintf("%s:%d-%d", path, line, endLine)
+	}
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
 // PrintTallyReportHuman writes a human-readable tally report to the given writer.
 func PrintTallyReportHuman(w io.Writer, r *TallyReport) {
 	fmt.Fprintln(w, "Guardian Tally Report")
@@ -155,3 +297,129 @@ func PrintHistoryReportHuman(w io.Writer, r *HistoryReport) {
 		fmt.Fprintln(w)
 	}
 }
+
+// PrintRulesReportHuman writes a human-readable rules listing to the given writer.
+func PrintRulesReportHuman(w io.Writer, r *RulesReport) {
+	fmt.Fprintln(w, "Guardian Rules")
+	fmt.Fprintln(w, "==============")
+	fmt.Fprintln(w)
+
+	if len(r.Rules) == 0 {
+		fmt.Fprintln(w, "No rules configured.")
+		return
+	}
+
+	for _, rule := range r.Rules {
+		state := rule.Severity
+		if !rule.Enabled {
+			state = "disabled"
+		}
+		fmt.Fprintf(w, "  [%s] %s (%s)\n", state, rule.ID, rule.Type)
+		if rule.Owner != "" {
+			fmt.Fprintf(w, "    Owner: %s\n", rule.Owner)
+		}
+		if len(rule.Tags) > 0 {
+			fmt.Fprintf(w, "    Tags: %s\n", strings.Join(rule.Tags, ", "))
+		}
+	}
+}
+
+// PrintRuleReportHuman writes a human-readable rule report to the given writer.
+func PrintRuleReportHuman(w io.Writer, r *RuleReport) {
+	title := "Rule: " + r.ID
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, strings.Repeat("=", len(title)))
+	fmt.Fprintln(w)
+
+	enabled := "yes"
+	if !r.Enabled {
+		enabled = "no"
+	}
+	fmt.Fprintf(w, "Description: %s\n", r.Description)
+	fmt.Fprintf(w, "Type:        %s\n", r.Type)
+	fmt.Fprintf(w, "Severity:    %s\n", r.Severity)
+	fmt.Fprintf(w, "Enabled:     %s\n", enabled)
+	if r.Owner != "" {
+		note := ""
+		if r.OwnerUnknown {
+			note = " (not a role in constitution.yml)"
+		}
+		fmt.Fprintf(w, "Owner:       %s%s\n", r.Owner, note)
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(w, "Tags:        %s\n", strings.Join(r.Tags, ", "))
+	}
+	if r.DocsURL != "" {
+		fmt.Fprintf(w, "Docs:        %s\n", r.DocsURL)
+	}
+	if r.Timeout != "" {
+		fmt.Fprintf(w, "Timeout:     %s\n", r.Timeout)
+	}
+	if r.Source != "" {
+		fmt.Fprintf(w, "Source:      %s\n", r.Source)
+	}
+	if r.Rationale != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Rationale:")
+		for _, line := range strings.Split(strings.TrimSpace(r.Rationale), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Active exceptions (%d):\n", len(r.Exceptions))
+	for _, e := range r.Exceptions {
+		expires := "permanent"
+		if e.ExpiresAt != "" {
+			expires = "expires " + e.ExpiresAt
+		}
+		fmt.Fprintf(w, "  %s: %s (%s) - %s\n", e.ID, strings.Join(e.Paths, ", "), expires, e.Reason)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Open proposals (%d):\n", len(r.Proposals))
+	for _, p := range r.Proposals {
+		fmt.Fprintf(w, "  [%s] %s (%s) - %s\n", p.ProposalType, p.ID, p.Status, p.Description)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "History (%d):\n", len(r.History))
+	for _, item := range r.History {
+		summary := ""
+		if item.Summary != "" {
+			summary = " - " + item.Summary
+		}
+		fmt.Fprintf(w, "  [%s] %s finalized %s%s\n", item.ProposalType, item.ProposalID, item.FinalizedAt, summary)
+	}
+}
+
+// PrintRuleTestReportHuman writes a human-readable rule test report to the
+// given writer. Unexpected violations are listed under failed examples.
+func PrintRuleTestReportHuman(w io.Writer, r *RuleTestReport) {
+	fmt.Fprintln(w, "Guardian Rule Tests")
+	fmt.Fprintln(w, "===================")
+	fmt.Fprintln(w)
+
+	if len(r.Results) == 0 {
+		fmt.Fprintln(w, "No rule examples found.")
+	}
+
+	for _, res := range r.Results {
+		if res.Passed {
+			fmt.Fprintf(w, "  PASS %s %s\n", res.RuleID, res.Example)
+			continue
+		}
+		fmt.Fprintf(w, "  FAIL %s %s: %s\n", res.RuleID, res.Example, res.Failure)
+		for _, v := range res.Violations {
+			fmt.Fprintf(w, "       %s %s\n", formatLocation(v.FilePath, v.Line, v.EndLine), strings.SplitN(v.DiffSnippet, "\n", 2)[0])
+		}
+	}
+	fmt.Fprintln(w)
+
+	if len(r.Untested) > 0 {
+		fmt.Fprintf(w, "Rules without examples: %s\n", strings.Join(r.Untested, ", "))
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Result: %d passed, %d failed\n", r.Passed, r.Failed)
+}
@@ -22,6 +22,24 @@ func TestPrintCheckReportHuman_NoViolations(t *testing.T) {
 	assert.Contains(t, out, "Result: PASSED")
 }
 
+func TestPrintCheckReportHuman_LineNumbers(t *testing.T) {
+	r := &CheckReport{
+		Violations: []ViolationReport{
+			{RuleID: "single", Severity: "error", FilePath: "a.go", Line: 12, EndLine: 12},
+			{RuleID: "range", Severity: "error", FilePath: "b.go", Line: 3, EndLine: 5},
+			{RuleID: "file", Severity: "error", FilePath: "c.go"},
+		},
+		Summary: ReportSummary{Errors: 3},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "File: a.go:12\n")
+	assert.Contains(t, out, "File: b.go:3-5\n")
+	assert.Contains(t, out, "File: c.go\n")
+}
+
 func TestPrintCheckReportHuman_WithViolations(t *testing.T) {
 	r := &CheckReport{
 		Violations: []ViolationReport{
@@ -114,6 +132,29 @@ func TestPrintCheckReportHuman_NoFilePath(t *testing.T) {
 	out := buf.String()
 
 	assert.NotContains(t, out, "File:")
+	assert.NotContains(t, out, "Commit:")
+}
+
+func TestPrintCheckReportHuman_Commit(t *testing.T) {
+	r := &CheckReport{
+		Violations: []ViolationReport{
+			{
+				RuleID:      "conventional_commits",
+				Severity:    "error",
+				Description: "Commits follow the conventions (work-in-progress commit)",
+				Commit:      "3f9a2c1d5e7b8a9c0d1e2f3a4b5c6d7e8f9a0b1c",
+				DiffSnippet: "WIP",
+			},
+		},
+		Summary: ReportSummary{Errors: 1, Passed: false},
+	}
+
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+	out := buf.String()
+
+	assert.NotContains(t, out, "File:")
+	assert.Contains(t, out, "  Commit: 3f9a2c1d5e7b8a9c0d1e2f3a4b5c6d7e8f9a0b1c\n")
 }
 
 func TestPrintCheckReportHuman_NoDiffSnippet(t *testing.T) {
@@ -389,3 +430,177 @@ func TestPrintHistoryReportHuman_NoSummary(t *testing.T) {
 
 	assert.NotContains(t, out, "Summary:")
 }
+
+func TestPrintCheckReportHuman_Baselined(t *testing.T) {
+	r := &CheckReport{
+		Baselined: []ViolationReport{
+			{RuleID: "money_minor_units", Severity: "warning", FilePath: "domain/Price.kt", Line: 4},
+		},
+		Summary: ReportSummary{Baselined: 1, Passed: true},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "No violations found.")
+	assert.Contains(t, out, "Baselined Violations")
+	assert.Contains(t, out, "1 known violation(s)")
+	assert.Contains(t, out, "[warning] money_minor_units domain/Price.kt:4")
+	assert.Contains(t, out, "Result: PASSED")
+}
+
+func TestPrintCheckReportHuman_RuleErrors(t *testing.T) {
+	r := &CheckReport{
+		RuleErrors: []RuleErrorReport{
+			{RuleID: "broken", RuleType: "diff_pattern_forbidden", Error: "invalid regex \"[\""},
+		},
+		Summary: ReportSummary{Passed: true},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "Rule Errors")
+	assert.Contains(t, out, `broken (diff_pattern_forbidden): invalid regex "["`)
+	assert.Contains(t, out, "Result: PASSED")
+}
+
+func TestPrintCheckReportHuman_RuleErrorsFailRun(t *testing.T) {
+	r := &CheckReport{
+		RuleErrors: []RuleErrorReport{{RuleID: "broken", RuleType: "unknown", Error: "unknown rule type"}},
+		Summary:    ReportSummary{Passed: false},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	assert.Contains(t, buf.String(), "Result: FAILED")
+}
+
+func TestPrintCheckReportHuman_Suppressions(t *testing.T) {
+	r := &CheckReport{
+		Violations: []ViolationReport{
+			{RuleID: "no_secrets", Severity: "error", Description: "No secrets", FilePath: "app.py", Line: 9},
+		},
+		Suppressions: []SuppressionReport{
+			{RuleID: "money_minor_units", Severity: "error", FilePath: "domain/Price.kt", Line: 4, CommentLine: 3, Reason: "legacy API"},
+			{RuleID: "no_secrets", Severity: "error", FilePath: "app.py", Line: 9, CommentLine: 9, Rejected: "inline suppression is not allowed for this rule"},
+		},
+		Summary: ReportSummary{Errors: 1, Suppressed: 1},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "Inline Suppressions")
+	assert.Contains(t, out, "1 violation(s) suppressed by guardian:ignore")
+	assert.Contains(t, out, `[error] money_minor_units domain/Price.kt:4 - "legacy API"`)
+	assert.Contains(t, out, "1 suppression(s) not applied:")
+	assert.Contains(t, out, "[error] no_secrets app.py:9: inline suppression is not allowed for this rule")
+}
+
+func TestPrintCheckReportHuman_Fixes(t *testing.T) {
+	r := &CheckReport{
+		Violations: []ViolationReport{
+			{RuleID: "money_minor_units", Severity: "error", Description: "No Double", FilePath: "domain/Price.kt", Line: 2},
+		},
+		Summary: ReportSummary{Errors: 1},
+		Fixes: &FixReport{
+			Applied: true,
+			Files:   1,
+			Lines: []FixedLineReport{
+				{RuleID: "money_minor_units", FilePath: "domain/Price.kt", Line: 2, Old: "val a: Double", New: "val a: Long"},
+			},
+			Skipped: []SkippedFixReport{
+				{RuleID: "money_minor_units", Severity: "error", FilePath: "domain/Price.kt", Line: 5, Reason: "line 5 has changed since the diff"},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	PrintCheckReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "Fixed 1 line(s) in 1 file(s):")
+	assert.Contains(t, out, "  money_minor_units domain/Price.kt:2\n    - val a: Double\n    + val a: Long\n")
+	assert.Contains(t, out, "1 violation(s) not fixed:")
+	assert.Contains(t, out, "[error] money_minor_units domain/Price.kt:5: line 5 has changed since the diff")
+}
+
+func TestPrintFixReportHuman_Patch(t *testing.T) {
+	var buf bytes.Buffer
+	PrintFixReportHuman(&buf, &FixReport{Files: 2, Lines: []FixedLineReport{{RuleID: "r", FilePath: "a.go", Line: 1}, {RuleID: "r", FilePath: "b.go", Line: 3}}})
+	assert.Contains(t, buf.String(), "Would fix 2 line(s) in 2 file(s):")
+
+	buf.Reset()
+	PrintFixReportHuman(&buf, &FixReport{})
+	assert.Contains(t, buf.String(), "No fixable violations.")
+}
+
+func TestPrintRulesReportHuman(t *testing.T) {
+	r := &RulesReport{Rules: []RuleSummary{
+		{ID: "no_secrets", Type: "diff_pattern_forbidden", Severity: "error", Enabled: true, Owner: "security", Tags: []string{"security", "api"}},
+		{ID: "old_rule", Type: "layers", Severity: "warning", Enabled: false},
+	}}
+	var buf bytes.Buffer
+	PrintRulesReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "[error] no_secrets (diff_pattern_forbidden)")
+	assert.Contains(t, out, "Owner: security")
+	assert.Contains(t, out, "Tags: security, api")
+	assert.Contains(t, out, "[disabled] old_rule (layers)")
+}
+
+func TestPrintRuleReportHuman(t *testing.T) {
+	r := &RuleReport{
+		RuleSummary:  RuleSummary{ID: "no_secrets", Type: "diff_pattern_forbidden", Severity: "error", Enabled: true, Owner: "sec"},
+		Description:  "No hardcoded secrets",
+		Rationale:    "Leaked credentials must be rotated.",
+		DocsURL:      "https://wiki.example.com/adr/12",
+		OwnerUnknown: true,
+		Exceptions: []ExceptionSummary{
+			{ID: "exc-1", Paths: []string{"test/**"}, Reason: "fixtures", ExpiresAt: "2030-01-01"},
+		},
+		Proposals: []ProposalSummary{{ID: "prop-1", ProposalType: "modify", Status: "proposed", Description: "Allow test keys"}},
+		History:   []HistoryItem{{ProposalID: "prop-0", ProposalType: "add", FinalizedAt: "2024-01-20", Summary: "accepted"}},
+	}
+	var buf bytes.Buffer
+	PrintRuleReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, "Rule: no_secrets\n================")
+	assert.Contains(t, out, "Owner:       sec (not a role in constitution.yml)")
+	assert.Contains(t, out, "Docs:        https://wiki.example.com/adr/12")
+	assert.Contains(t, out, "Rationale:\n  Leaked credentials must be rotated.")
+	assert.Contains(t, out, "Active exceptions (1):\n  exc-1: test/** (expires 2030-01-01) - fixtures")
+	assert.Contains(t, out, "Open proposals (1):\n  [modify] prop-1 (proposed) - Allow test keys")
+	assert.Contains(t, out, "History (1):\n  [add] prop-0 finalized 2024-01-20 - accepted")
+}
+
+func TestPrintRuleTestReportHuman(t *testing.T) {
+	r := &RuleTestReport{
+		Results: []RuleTestResult{
+			{RuleID: "money", Example: `example 1 ("double field")`, Expect: "violation", Passed: true},
+			{
+				RuleID:  "money",
+				Example: "example 2",
+				Expect:  "pass",
+				Failure: "expected no violation, got 1",
+				Violations: []ViolationReport{
+					{FilePath: "domain/Price.kt", Line: 1, DiffSnippet: "+val a: Double"},
+				},
+			},
+		},
+		Untested: []string{"no_todo"},
+		Passed:   1,
+		Failed:   1,
+	}
+	var buf bytes.Buffer
+	PrintRuleTestReportHuman(&buf, r)
+
+	out := buf.String()
+	assert.Contains(t, out, `PASS money example 1 ("double field")`)
+	assert.Contains(t, out, "FAIL money example 2: expected no violation, got 1")
+	assert.Contains(t, out, "domain/Price.kt:1 +val a: Double")
+	assert.Contains(t, out, "Rules without examples: no_todo")
+	assert.Contains(t, out, "Result: 1 passed, 1 failed")
+}
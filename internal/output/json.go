@@ -26,6 +26,21 @@ func PrintHistoryReportJSON(w io.Writer, r *HistoryReport) error {
 	return writeJSON(w, r)
 }
 
+// PrintRulesReportJSON writes a JSON-formatted rules listing to the given writer.
+func PrintRulesReportJSON(w io.Writer, r *RulesReport) error {
+	return writeJSON(w, r)
+}
+
+// PrintRuleReportJSON writes a JSON-formatted rule report to the given writer.
+func PrintRuleReportJSON(w io.Writer, r *RuleReport) error {
+	return writeJSON(w, r)
+}
+
+// PrintRuleTestReportJSON writes a JSON-formatted rule test report to the given writer.
+func PrintRuleTestReportJSON(w io.Writer, r *RuleTestReport) error {
+	return writeJSON(w, r)
+}
+
 // writeJSON encodes the given value as indented JSON and writes it to w.
 func writeJSON(w io.Writer, v interface{}) error {
 	encoder := json.NewEncoder(w)
@@ -77,6 +77,29 @@ func TestPrintCheckReportJSON_WithViolations(t *testing.T) {
 	assert.False(t, decoded.Summary.Passed)
 }
 
+func TestPrintCheckReportJSON_LineNumbers(t *testing.T) {
+	r := &CheckReport{
+		Violations: []ViolationReport{
+			{RuleID: "with_line", FilePath: "a.go", Line: 7, EndLine: 9},
+			{RuleID: "file_level", FilePath: "b.go"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintCheckReportJSON(&buf, r))
+
+	var raw struct {
+		Violations []map[string]interface{} `json:"violations"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+
+	require.Len(t, raw.Violations, 2)
+	assert.Equal(t, float64(7), raw.Violations[0]["line"])
+	assert.Equal(t, float64(9), raw.Violations[0]["end_line"])
+	assert.NotContains(t, raw.Violations[1], "line")
+	assert.NotContains(t, raw.Violations[1], "end_line")
+}
+
 func TestPrintCheckReportJSON_ValidJSON(t *testing.T) {
 	r := &CheckReport{
 		Violations: []ViolationReport{
@@ -423,3 +446,44 @@ func TestTallyReportJSONFieldNames(t *testing.T) {
 	assert.Contains(t, out, `"decision"`)
 	assert.Contains(t, out, `"comment"`)
 }
+
+func TestPrintCheckReportJSON_Baselined(t *testing.T) {
+	r := &CheckReport{
+		Baselined: []ViolationReport{
+			{RuleID: "money_minor_units", Severity: "warning", FilePath: "domain/Price.kt"},
+		},
+		Summary: ReportSummary{Baselined: 1, Passed: true},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintCheckReportJSON(&buf, r))
+
+	var decoded CheckReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Baselined, 1)
+	assert.Equal(t, "money_minor_units", decoded.Baselined[0].RuleID)
+	assert.Equal(t, 1, decoded.Summary.Baselined)
+}
+
+func TestPrintCheckReportJSON_RuleErrors(t *testing.T) {
+	r := &CheckReport{
+		RuleErrors: []RuleErrorReport{
+			{RuleID: "broken", RuleType: "diff_pattern_forbidden", Error: "missing config key"},
+		},
+		Summary: ReportSummary{Passed: true},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintCheckReportJSON(&buf, r))
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+
+	ruleErrors, ok := raw["rule_errors"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, ruleErrors, 1)
+	entry := ruleErrors[0].(map[string]interface{})
+	assert.Equal(t, "broken", entry["rule_id"])
+	assert.Equal(t, "diff_pattern_forbidden", entry["rule_type"])
+	assert.Equal(t, "missing config key", entry["error"])
+}
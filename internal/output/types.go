@@ -4,9 +4,47 @@ package output
 
 // CheckReport contains the results of a guardian check operation.
 type CheckReport struct {
-	Violations      []ViolationReport `json:"violations"`
+	Violations []ViolationReport `json:"violations"`
+	// Baselined lists known violations recorded in .agreements/baseline.yml.
+	// They are reported for information and do not fail the check.
+	Baselined []ViolationReport `json:"baselined,omitempty"`
+	// Suppressions lists inline guardian:ignore comments that matched a
+	// violation. Rejected suppressions leave their violation in Violations.
+	Suppressions []SuppressionReport `json:"suppressions,omitempty"`
+	// RuleErrors lists rules that could not be evaluated.
+	RuleErrors      []RuleErrorReport `json:"rule_errors,omitempty"`
 	Summary         ReportSummary     `json:"summary"`
 	ProposalContext *ProposalContext  `json:"proposal_context,omitempty"`
+	// Fixes describes the changes made by guardian check --fix.
+	Fixes *FixReport `json:"fixes,omitempty"`
+}
+
+// FixReport describes the fixes for a check's violations.
+type FixReport struct {
+	// Applied is true if the fixes were written to the working tree, and
+	// false if they were only printed as a patch.
+	Applied bool               `json:"applied"`
+	Files   int                `json:"files"`
+	Lines   []FixedLineReport  `json:"lines"`
+	Skipped []SkippedFixReport `json:"skipped,omitempty"`
+}
+
+// FixedLineReport is a line changed by a rule's fix.
+type FixedLineReport struct {
+	RuleID   string `json:"rule_id"`
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+}
+
+// SkippedFixReport is a violation whose rule has a fix that was not applied.
+type SkippedFixReport struct {
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"`
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line,omitempty"`
+	Reason   string `json:"reason"`
 }
 
 // ProposalContext provides governance context about active proposals.
@@ -31,15 +69,40 @@ type ViolationReport struct {
 	Severity       string `json:"severity"`
 	Description    string `json:"description"`
 	FilePath       string `json:"file_path"`
+	Line           int    `json:"line,omitempty"`
+	EndLine        int    `json:"end_line,omitempty"`
+	Commit         string `json:"commit,omitempty"`
 	DiffSnippet    string `json:"diff_snippet"`
 	LLMExplanation string `json:"llm_explanation"`
 }
 
+// SuppressionReport describes a violation matched by an inline
+// guardian:ignore comment.
+type SuppressionReport struct {
+	RuleID      string `json:"rule_id"`
+	Severity    string `json:"severity"`
+	FilePath    string `json:"file_path"`
+	Line        int    `json:"line"`
+	CommentLine int    `json:"comment_line"`
+	Reason      string `json:"reason,omitempty"`
+	// Rejected explains why the constitution did not allow the suppression.
+	Rejected string `json:"rejected,omitempty"`
+}
+
+// RuleErrorReport describes a rule that failed to run.
+type RuleErrorReport struct {
+	RuleID   string `json:"rule_id"`
+	RuleType string `json:"rule_type"`
+	Error    string `json:"error"`
+}
+
 // ReportSummary summarizes the check results.
 type ReportSummary struct {
-	Errors   int  `json:"errors"`
-	Warnings int  `json:"warnings"`
-	Passed   bool `json:"passed"`
+	Errors     int  `json:"errors"`
+	Warnings   int  `json:"warnings"`
+	Baselined  int  `json:"baselined,omitempty"`
+	Suppressed int  `json:"suppressed,omitempty"` // violations suppressed by inline comments
+	Passed     bool `json:"passed"`
 }
 
 // TallyReport contains the voting results for a proposal.
@@ -90,3 +153,63 @@ type HistoryItem struct {
 	FinalizedAt  string `json:"finalized_at"`
 	Summary      string `json:"summary"`
 }
+
+// RulesReport lists the effective rules.
+type RulesReport struct {
+	Rules []RuleSummary `json:"rules"`
+}
+
+// RuleSummary describes a rule in a rules listing.
+type RuleSummary struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Severity string   `json:"severity"`
+	Enabled  bool     `json:"enabled"`
+	Owner    string   `json:"owner,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Source   string   `json:"source,omitempty"`
+}
+
+// RuleReport describes a single rule together with its governance state.
+type RuleReport struct {
+	RuleSummary
+	Description string                 `json:"description"`
+	Rationale   string                 `json:"rationale,omitempty"`
+	DocsURL     string                 `json:"docs_url,omitempty"`
+	Timeout     string                 `json:"timeout,omitempty"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+	// OwnerUnknown is set if Owner is not a role in the constitution.
+	OwnerUnknown bool               `json:"owner_unknown,omitempty"`
+	Exceptions   []ExceptionSummary `json:"exceptions"` // active exceptions
+	Proposals    []ProposalSummary  `json:"proposals"`  // proposed or accepted, not yet finalized
+	History      []HistoryItem      `json:"history"`    // finalized proposals
+}
+
+// ExceptionSummary is a lightweight representation of an exception.
+type ExceptionSummary struct {
+	ID        string   `json:"id"`
+	Paths     []string `json:"paths"`
+	Reason    string   `json:"reason"`
+	CreatedBy string   `json:"created_by"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+}
+
+// RuleTestReport contains the results of guardian rules test.
+type RuleTestReport struct {
+	Results []RuleTestResult `json:"results"`
+	// Untested lists the rules that have no examples.
+	Untested []string `json:"untested,omitempty"`
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+}
+
+// RuleTestResult is the outcome of a single rule example.
+type RuleTestResult struct {
+	RuleID  string `json:"rule_id"`
+	Example string `json:"example"`
+	Expect  string `json:"expect"`
+	Passed  bool   `json:"passed"`
+	Failure string `json:"failure,omitempty"`
+	// Violations lists what the rule reported for the example.
+	Violations []ViolationReport `json:"violations,omitempty"`
+}